@@ -1,5 +1,247 @@
 package constant
 
+import "time"
+
 const (
 	ConfigPort = ":3000"
 )
+
+// auth
+const (
+	TokenExpiryDuration    = 24 * time.Hour
+	PasswordSaltLength     = 16
+	PasswordHashIterations = 100000
+	MinPasswordLength      = 8
+)
+
+type contextKey string
+
+// AuthUserContextKey is the context key holding the authenticated entity.AuthUser
+const AuthUserContextKey contextKey = "auth-user"
+
+// goal validation limits
+const (
+	MaxGoalYearsLeft     = 100
+	MaxPercentageAllowed = 100
+)
+
+// fire calculator defaults, callers can override them per request
+const (
+	DefaultFireGrowthRatePercentage = 10.0
+	DefaultLeanFireMultiplier       = 15.0
+	DefaultFireMultiplier           = 25.0
+	DefaultFatFireMultiplier        = 50.0
+)
+
+// goal roll forward scheduler, runs during January so each plan year is rolled once.
+// It is off unless the GoalRollForwardSchedulerEnv environment variable is set to true
+const (
+	GoalRollForwardSchedulerEnv      = "GOAL_ROLL_FORWARD_SCHEDULER_ENABLED"
+	GoalRollForwardSchedulerInterval = 24 * time.Hour
+	GoalRollForwardMonth             = time.January
+)
+
+// net worth snapshot scheduler, each user gets one snapshot per month.
+// It is off unless the NetWorthSnapshotSchedulerEnv environment variable is set to true
+const (
+	NetWorthSnapshotSchedulerEnv      = "NET_WORTH_SNAPSHOT_SCHEDULER_ENABLED"
+	NetWorthSnapshotSchedulerInterval = 24 * time.Hour
+)
+
+// UncategorisedSubCategoryName labels holdings which are not linked to a sub category
+const UncategorisedSubCategoryName = "Uncategorised"
+
+// cashflow categories
+const (
+	CashflowCategorySalary        = "salary"
+	CashflowCategoryRent          = "rent"
+	CashflowCategoryEMI           = "emi"
+	CashflowCategoryDiscretionary = "discretionary"
+	CashflowCategoryOther         = "other"
+)
+
+var CashflowCategories = []string{
+	CashflowCategorySalary,
+	CashflowCategoryRent,
+	CashflowCategoryEMI,
+	CashflowCategoryDiscretionary,
+	CashflowCategoryOther,
+}
+
+// MaxLoanTenureInMonths caps the emi plan of a liability at 50 years
+const MaxLoanTenureInMonths = 600
+
+// liquidity types of an investment, stored in lower case
+const (
+	LiquidityTypeLiquid   = "liquid"
+	LiquidityTypeIlliquid = "illiquid"
+)
+
+// AllocationSumTolerance absorbs floating point noise when checking allocations sum to 100
+const AllocationSumTolerance = 0.01
+
+// monte carlo simulation of goal success, a fixed default seed keeps the result reproducible
+const (
+	MonteCarloDefaultSeed        = int64(42)
+	MonteCarloDefaultSimulations = 1000
+	MonteCarloMaxSimulations     = 10000
+)
+
+// sip sources reported by the goal simulation
+const (
+	SIPSourceCurrent  = "current_sip"
+	SIPSourceRequired = "required_sip"
+)
+
+// DefaultGoalPriority is used when a goal is saved without a priority, 1 is funded first
+const DefaultGoalPriority = 1
+
+// funding policies of the sip allocator when the investing surplus cannot cover every goal
+const (
+	SIPFundingPolicyPriority = "priority"
+	SIPFundingPolicyProRata  = "pro-rata"
+)
+
+// MaxGoalCatchUpYears is how long after its due date an underfunded goal is followed before it is reported unreachable
+const MaxGoalCatchUpYears = 50
+
+// rebalancing modes, sip-only never sells and only directs fresh money to the underweight asset classes
+const (
+	RebalanceModeFull    = "full"
+	RebalanceModeSIPOnly = "sip-only"
+)
+
+const (
+	TradeActionBuy  = "buy"
+	TradeActionSell = "sell"
+)
+
+// DefaultMinTradeAmount skips trades too small to be worth placing
+const DefaultMinTradeAmount = 500.0
+
+// investment transaction types, buy and sip add units, sell removes them and dividend is a payout
+const (
+	TransactionTypeBuy      = "buy"
+	TransactionTypeSell     = "sell"
+	TransactionTypeDividend = "dividend"
+	TransactionTypeSIP      = "sip"
+)
+
+var TransactionTypes = []string{
+	TransactionTypeBuy,
+	TransactionTypeSell,
+	TransactionTypeDividend,
+	TransactionTypeSIP,
+}
+
+// AMFINAVDateLayout is the date format of the amfi NAVAll file, e.g. 17-Oct-2025
+const AMFINAVDateLayout = "02-Jan-2006"
+
+// MaxNAVUploadSizeInBytes caps the request body of a nav upload, the full NAVAll file is around 2 MB
+const MaxNAVUploadSizeInBytes = 32 << 20
+
+// cashflow sources, bank_import cashflows are replaced on every statement import
+const (
+	CashflowSourceManual     = "manual"
+	CashflowSourceBankImport = "bank_import"
+)
+
+// bank statement import
+const (
+	DefaultBankStatementDateFormat = "02/01/2006"
+	// MaxBankStatementUploadSizeInBytes caps the request body of a statement upload
+	MaxBankStatementUploadSizeInBytes = 10 << 20
+	// a category is recurring when it shows up in at least RecurringCashflowMinMonths of the last RecurringCashflowLookbackMonths
+	RecurringCashflowLookbackMonths = 3
+	RecurringCashflowMinMonths      = 2
+)
+
+const DefaultCashflowRulePriority = 1
+
+// cashflow frequencies, one-time cashflows happen only in the month of their start date
+const (
+	CashflowFrequencyMonthly   = "monthly"
+	CashflowFrequencyQuarterly = "quarterly"
+	CashflowFrequencyAnnual    = "annual"
+	CashflowFrequencyOneTime   = "one-time"
+)
+
+var CashflowFrequencies = []string{
+	CashflowFrequencyMonthly,
+	CashflowFrequencyQuarterly,
+	CashflowFrequencyAnnual,
+	CashflowFrequencyOneTime,
+}
+
+// surplus projection
+const (
+	DefaultSurplusProjectionYears = 5
+	MaxSurplusProjectionYears     = 50
+)
+
+// investing surplus the sip allocator funds goals from, projected is the average monthly surplus of the next 12 months
+const (
+	SurplusModeCurrent   = "current"
+	SurplusModeProjected = "projected"
+)
+
+// income tax regimes
+const (
+	TaxRegimeOld = "old"
+	TaxRegimeNew = "new"
+)
+
+var TaxRegimes = []string{TaxRegimeOld, TaxRegimeNew}
+
+// deduction sections an investment can be tagged with, they only reduce taxable income under the old regime
+const (
+	TaxSection80C     = "80C"
+	TaxSection80D     = "80D"
+	TaxSection80CCD1B = "80CCD(1B)" // additional nps contribution
+)
+
+var TaxSections = []string{TaxSection80C, TaxSection80D, TaxSection80CCD1B}
+
+// TaxSectionLimits is the yearly cap of every deduction section, 80D is the self and family cap below 60 years
+var TaxSectionLimits = map[string]float64{
+	TaxSection80C:     150000,
+	TaxSection80D:     25000,
+	TaxSection80CCD1B: 50000,
+}
+
+// FinancialYearStartMonth is the first month of an indian financial year, written like 2025-26
+const FinancialYearStartMonth = time.April
+
+// return mode of the sip allocator, post-tax takes the capital gains tax of every asset class off its return
+const (
+	ReturnModePreTax  = "pre-tax"
+	ReturnModePostTax = "post-tax"
+)
+
+// CostInflationIndexGrowthPercentage is the assumed yearly growth of the cost inflation index, used for projections
+// and to extrapolate the years not in CostInflationIndex yet
+const CostInflationIndexGrowthPercentage = 5.0
+
+// CostInflationIndex is the cost inflation index notified for every financial year, 2001-02 being the base year
+var CostInflationIndex = map[string]float64{
+	"2001-02": 100, "2002-03": 105, "2003-04": 109, "2004-05": 113, "2005-06": 117,
+	"2006-07": 122, "2007-08": 129, "2008-09": 137, "2009-10": 148, "2010-11": 167,
+	"2011-12": 184, "2012-13": 200, "2013-14": 220, "2014-15": 240, "2015-16": 254,
+	"2016-17": 264, "2017-18": 272, "2018-19": 280, "2019-20": 289, "2020-21": 301,
+	"2021-22": 317, "2022-23": 331, "2023-24": 348, "2024-25": 363, "2025-26": 376,
+}
+
+// capital gain terms, a gain is long term when the lot is held longer than the holding period of its asset class
+const (
+	CapitalGainTermShort = "short"
+	CapitalGainTermLong  = "long"
+)
+
+// emergency fund, the top-up goal gets the reserved priority 0 so the sip allocator funds it before every other goal
+const (
+	DefaultEmergencyFundTargetMonths = 6.0
+	MaxEmergencyFundTargetMonths     = 60.0
+	EmergencyFundGoalPriority        = 0
+	EmergencyFundTopUpYears          = 1
+	EmergencyFundTopUpGoalName       = "Emergency fund top-up"
+)
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date, read and written as YYYY-MM-DD in json
+type Date struct {
+	time.Time
+}
+
+func NewDate(t time.Time) Date {
+	return Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(DateLayout))
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return fmt.Errorf("date must be in YYYY-MM-DD format")
+	}
+
+	*d = NewDate(parsed)
+	return nil
+}
+
+func (d *Date) Scan(src interface{}) error {
+	value, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into Date", src)
+	}
+
+	*d = NewDate(value)
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+	return d.Time, nil
+}
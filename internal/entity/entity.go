@@ -1,6 +1,9 @@
 package entity
 
-var jwtKey = []byte("my_secret_key")
+import "time"
+
+// JwtKey is the HMAC secret used to sign and verify the session tokens
+var JwtKey = []byte("my_secret_key")
 
 type ApiResponse struct {
 	Data    interface{}          `json:"data"`
@@ -12,10 +15,31 @@ type CommonErrorResponse struct {
 	Message string `json:"message"`
 }
 
+// CustomError carries the http status code the handler should respond with
+type CustomError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *CustomError) Error() string {
+	return e.Message
+}
+
 type AssetClass struct {
 	ID                         int64   `json:"id"`                            // bigint corresponds to int64 in Go
 	Name                       string  `json:"name"`                          // varchar corresponds to string
 	ExpectedReturnInPercentage float64 `json:"expected_return_in_percentage"` // double precision corresponds to float64
+	VolatilityInPercentage     float64 `json:"volatility_in_percentage"`      // yearly standard deviation of the return
+	CapitalGainsTaxProfile
+}
+
+// CapitalGainsTaxProfile is how the gains of an asset class are taxed on redemption
+type CapitalGainsTaxProfile struct {
+	STCGRateInPercentage      float64 `json:"stcg_rate_in_percentage"`
+	LTCGRateInPercentage      float64 `json:"ltcg_rate_in_percentage"`
+	LTCGHoldingPeriodInMonths int64   `json:"ltcg_holding_period_in_months"` // gains held at least this long are long term
+	LTCGExemptionLimit        float64 `json:"ltcg_exemption_limit"`          // long term gains exempt every financial year
+	Indexation                bool    `json:"indexation"`                    // long term cost is indexed to inflation
 }
 
 type Goals struct {
@@ -27,6 +51,16 @@ type Goals struct {
 	TodayAmount         float64 `json:"today_amount"`           // double precision corresponds to float64
 	AllocatedAmount     float64 `json:"allocated_amount"`       // double precision corresponds to float64
 	SIPStepUpPercentage float64 `json:"sip_step_up_percentage"` // double precision corresponds to float64
+	IsDue               bool    `json:"is_due"`                 // set once years_left reaches zero
+	CurrentSIP          float64 `json:"current_sip"`            // monthly sip actually invested towards the goal
+	Priority            int64   `json:"priority"`               // 1 is funded first when the surplus is short, 0 is kept for the emergency fund top-up
+}
+
+type GoalRollForward struct {
+	ID           int64     `json:"id"`
+	PlanYear     int       `json:"plan_year"`
+	GoalsUpdated int64     `json:"goals_updated"`
+	RanAt        time.Time `json:"ran_at"`
 }
 
 type AllocationType struct {
@@ -34,7 +68,7 @@ type AllocationType struct {
 	Name        string `json:"name"`        // varchar corresponds to string
 	Description string `json:"description"` // double precision corresponds to float64
 	MinAge      int64  `json:"min_age"`
-	MaxAge      int64  `json:"max_age"`
+	MaxAge      *int64 `json:"max_age"` // nil for the open ended band
 }
 
 type AllocationTypeConfig struct {
@@ -55,6 +89,8 @@ type InvestableAssetAllocationAPIResponse struct {
 	AssetName string            `json:"asset_name"` // varchar corresponds to string
 	Current   ValueContribution `json:"current"`
 	Required  ValueContribution `json:"required"`
+
+	SubCategories []InvestableSubCategoryAllocation `json:"sub_categories"`
 }
 
 type ValueContribution struct {
@@ -62,11 +98,518 @@ type ValueContribution struct {
 	ContributionPercentage float64 `json:"contribution_percentage"` // varchar corresponds to string
 }
 
+type FireRequest struct {
+	CurrentAge           int     `json:"current_age"`
+	RetirementAge        int     `json:"retirement_age"`
+	EarlyRetirementAge   int     `json:"early_retirement_age"`
+	MonthlyExpense       float64 `json:"monthly_expense"`
+	InflationPercentage  float64 `json:"inflation_percentage"`
+	GrowthRatePercentage float64 `json:"growth_rate_percentage"` // used to discount the fire corpus to the early retirement age
+	LeanFireMultiplier   float64 `json:"lean_fire_multiplier"`
+	FireMultiplier       float64 `json:"fire_multiplier"`
+	FatFireMultiplier    float64 `json:"fat_fire_multiplier"`
+}
+
 type FireResponse struct {
-	YearlyExpense           float64
-	RetirementYearlyExpense float64
-	LeanFire                float64
-	Fire                    float64
-	FatFire                 float64
-	EarlyRetirementAmount   float64
+	YearlyExpense           float64 `json:"yearly_expense"`
+	RetirementYearlyExpense float64 `json:"retirement_yearly_expense"`
+	LeanFire                float64 `json:"lean_fire"`
+	Fire                    float64 `json:"fire"`
+	FatFire                 float64 `json:"fat_fire"`
+	EarlyRetirementAmount   float64 `json:"early_retirement_amount"`
+}
+
+type AssetSubCategory struct {
+	ID                 int64   `json:"id"`
+	AssetClassId       int64   `json:"asset_class_id"`
+	Name               string  `json:"name"`
+	PriorityOrder      int     `json:"priority_order"`       // lower value is filled first
+	WeightInPercentage float64 `json:"weight_in_percentage"` // relative share of the asset class amount
+}
+
+type SubCategoryAllocation struct {
+	SubCategoryId   int64   `json:"sub_category_id"`
+	SubCategoryName string  `json:"sub_category_name"`
+	PriorityOrder   int     `json:"priority_order"`
+	Value           float64 `json:"value"`
+}
+
+type InvestableSubCategoryAllocation struct {
+	SubCategoryId   int64   `json:"sub_category_id"` // 0 for holdings without a sub category
+	SubCategoryName string  `json:"sub_category_name"`
+	PriorityOrder   int     `json:"priority_order"`
+	CurrentValue    float64 `json:"current_value"`
+	RequiredValue   float64 `json:"required_value"`
+}
+
+type SubCategoryHolding struct {
+	AssetId                int64   `json:"asset_id"`
+	AssetName              string  `json:"asset_name"`
+	SubCategoryId          int64   `json:"sub_category_id"` // 0 for holdings without a sub category
+	SubCategoryName        string  `json:"sub_category_name"`
+	LiquidValue            float64 `json:"liquid_value"`
+	IlliquidValue          float64 `json:"illiquid_value"`
+	Value                  float64 `json:"value"`
+	ContributionPercentage float64 `json:"contribution_percentage"`
+}
+
+// Cashflow amounts are today's amount, or the starting amount when the cashflow starts later.
+// The growth rate compounds on every anniversary of the start date
+type Cashflow struct {
+	ID                     int64   `json:"id"`
+	Name                   string  `json:"name"`
+	Amount                 float64 `json:"amount"` // paid once per frequency period
+	IsInflow               bool    `json:"is_inflow"`
+	Category               string  `json:"category"`
+	Source                 string  `json:"source"`     // manual, or bank_import when derived from imported statements
+	Frequency              string  `json:"frequency"`  // monthly, quarterly, annual or one-time
+	StartDate              *Date   `json:"start_date"` // anchors the payment months, a one-time cashflow happens in this month
+	EndDate                *Date   `json:"end_date"`   // nothing is paid after this date
+	GrowthRateInPercentage float64 `json:"growth_rate_in_percentage"`
+}
+
+type CashflowCategorySummary struct {
+	Category                 string  `json:"category"`
+	Inflow                   float64 `json:"inflow"`
+	Outflow                  float64 `json:"outflow"`
+	Net                      float64 `json:"net"`
+	OutflowShareInPercentage float64 `json:"outflow_share_in_percentage"`
+}
+
+type Liability struct {
+	ID                       int64   `json:"id"`
+	Name                     string  `json:"name"`
+	Amount                   float64 `json:"amount"` // principal borrowed on the start date
+	DueDate                  *Date   `json:"due_date"`
+	IsLongTerm               bool    `json:"is_long_term"`
+	InterestRateInPercentage float64 `json:"interest_rate_in_percentage"` // annual rate
+	TenureInMonths           int     `json:"tenure_in_months"`            // 0 for liabilities without an emi plan
+	EMI                      float64 `json:"emi"`
+	StartDate                *Date   `json:"start_date"` // date of the first emi
+	OutstandingPrincipal     float64 `json:"outstanding_principal"`
+}
+
+type AmortizationEntry struct {
+	Month          int     `json:"month"`
+	Date           Date    `json:"date"`
+	OpeningBalance float64 `json:"opening_balance"`
+	EMI            float64 `json:"emi"`
+	Principal      float64 `json:"principal"`
+	Interest       float64 `json:"interest"`
+	ClosingBalance float64 `json:"closing_balance"`
+}
+
+type AmortizationSchedule struct {
+	LiabilityId          int64               `json:"liability_id"`
+	LiabilityName        string              `json:"liability_name"`
+	EMI                  float64             `json:"emi"`
+	TotalInterest        float64             `json:"total_interest"`
+	OutstandingPrincipal float64             `json:"outstanding_principal"`
+	Schedule             []AmortizationEntry `json:"schedule"`
+}
+
+type Investment struct {
+	ID                 int64    `json:"id"`
+	AssetId            int64    `json:"asset_id"`
+	AssetName          string   `json:"asset_name"`
+	Name               string   `json:"name"`
+	Amount             float64  `json:"amount"`
+	Type               string   `json:"type"` // liquidity type, liquid or illiquid
+	AssetSubCategoryId *int64   `json:"asset_sub_category_id"`
+	SchemeCode         *string  `json:"scheme_code"` // amfi scheme code of a mutual fund holding
+	Units              *float64 `json:"units"`       // with a scheme code the amount is units x latest nav
+	TaxSection         *string  `json:"tax_section"` // 80C, 80D or 80CCD(1B) when contributions are tax deductible
+	IsEmergencyFund    bool     `json:"is_emergency_fund"`
+}
+
+type AllocationTypeWithConfig struct {
+	AllocationType
+	Config []AllocationTypeConfig `json:"config"`
+}
+
+// AllocationTypeBlend makes the return of an allocation type a weighted blend of other allocation types
+type AllocationTypeBlend struct {
+	AllocationTypeId            int64   `json:"allocation_type_id"`
+	AllocationTypeName          string  `json:"allocation_type_name"`
+	ComponentAllocationTypeId   int64   `json:"component_allocation_type_id"`
+	ComponentAllocationTypeName string  `json:"component_allocation_type_name"`
+	WeightInPercentage          float64 `json:"weight_in_percentage"`
+}
+
+// AssetClassCorrelation is stored once per pair with AssetClassIdA < AssetClassIdB, missing pairs are uncorrelated
+type AssetClassCorrelation struct {
+	AssetClassIdA int64   `json:"asset_class_id_a"`
+	AssetClassIdB int64   `json:"asset_class_id_b"`
+	Correlation   float64 `json:"correlation"`
+}
+
+// SimulationAsset is one asset class of the goal portfolio fed to the monte carlo simulation
+type SimulationAsset struct {
+	ExpectedReturnInPercentage float64
+	VolatilityInPercentage     float64
+	WeightInPercentage         float64
+}
+
+type CorpusPercentiles struct {
+	P10 float64 `json:"p10"`
+	P25 float64 `json:"p25"`
+	P50 float64 `json:"p50"`
+	P75 float64 `json:"p75"`
+	P90 float64 `json:"p90"`
+}
+
+type GoalSimulationResult struct {
+	GoalId                         int64             `json:"goal_id"`
+	GoalName                       string            `json:"goal_name"`
+	YearsLeft                      int64             `json:"years_left"`
+	TargetAmount                   float64           `json:"target_amount"`
+	MonthlySIP                     float64           `json:"monthly_sip"`
+	SIPSource                      string            `json:"sip_source"`
+	SuccessProbabilityInPercentage float64           `json:"success_probability_in_percentage"`
+	Corpus                         CorpusPercentiles `json:"corpus"`
+}
+
+// GlidePathYear is one year of a goal, invested as per the allocation type band of the years left at its start
+type GlidePathYear struct {
+	Year               int64                  `json:"year"`
+	YearsLeft          int64                  `json:"years_left"`
+	AllocationTypeId   int64                  `json:"allocation_type_id"`
+	AllocationTypeName string                 `json:"allocation_type_name"`
+	ReturnInPercentage float64                `json:"return_in_percentage"`
+	MonthlySIP         float64                `json:"monthly_sip"`
+	ProjectedCorpus    float64                `json:"projected_corpus"` // corpus built by the sip at the end of the year
+	AssetSplit         []AllocationTypeConfig `json:"asset_split"`
+}
+
+type GoalGlidePath struct {
+	GoalId         int64           `json:"goal_id"`
+	GoalName       string          `json:"goal_name"`
+	YearsLeft      int64           `json:"years_left"`
+	TargetAmount   float64         `json:"target_amount"`
+	RequiredAmount float64         `json:"required_amount"`
+	GlidePathSIP   float64         `json:"glide_path_sip"`
+	FlatSIP        float64         `json:"flat_sip"` // sip if today's allocation type was kept for the whole horizon
+	Path           []GlidePathYear `json:"path"`
+}
+
+// GoalFunding is how much of the sip required by a goal the investing surplus can cover
+type GoalFunding struct {
+	GoalId                  int64   `json:"goal_id"`
+	GoalName                string  `json:"goal_name"`
+	Priority                int64   `json:"priority"`
+	TargetAmount            float64 `json:"target_amount"`
+	RequiredSIP             float64 `json:"required_sip"`
+	FundedSIP               float64 `json:"funded_sip"`
+	ShortfallSIP            float64 `json:"shortfall_sip"`
+	AchievableCorpus        float64 `json:"achievable_corpus"`          // corpus at years_left with the funded sip
+	DelayedCompletionYear   *int64  `json:"delayed_completion_year"`    // nil when fully funded or not reachable
+	NotReachableWithinYears *int64  `json:"not_reachable_within_years"` // set when the funded sip does not catch up within that many years after the due date
+}
+
+type RebalanceTrade struct {
+	AssetId       int64                   `json:"asset_id"`
+	AssetName     string                  `json:"asset_name"`
+	Action        string                  `json:"action"` // buy or sell
+	Amount        float64                 `json:"amount"`
+	SubCategories []SubCategoryAllocation `json:"sub_categories"`
+}
+
+type RebalancedAllocation struct {
+	AssetId   int64             `json:"asset_id"`
+	AssetName string            `json:"asset_name"`
+	Current   ValueContribution `json:"current"`
+	Target    ValueContribution `json:"target"`
+	After     ValueContribution `json:"after"`
+}
+
+// NetWorthSnapshot is the net worth of a user as taken once per month
+type NetWorthSnapshot struct {
+	ID            int64                `json:"id"`
+	SnapshotMonth Date                 `json:"snapshot_month"` // first day of the month
+	TotalAsset    float64              `json:"total_asset"`
+	LiquidAsset   float64              `json:"liquid_asset"`
+	IlliquidAsset float64              `json:"illiquid_asset"`
+	Liabilities   float64              `json:"liabilities"`
+	NetWorth      float64              `json:"net_worth"`
+	CreatedAt     time.Time            `json:"created_at"`
+	AssetClasses  []NetWorthAssetClass `json:"asset_classes"`
+}
+
+type NetWorthAssetClass struct {
+	AssetId       int64   `json:"asset_id"`
+	AssetName     string  `json:"asset_name"`
+	LiquidValue   float64 `json:"liquid_value"`
+	IlliquidValue float64 `json:"illiquid_value"`
+	Value         float64 `json:"value"`
+}
+
+// NetWorthChange is the difference against an earlier snapshot, asset classes are keyed by name
+type NetWorthChange struct {
+	NetWorth           float64            `json:"net_worth"`
+	NetWorthPercentage float64            `json:"net_worth_percentage"` // 0 when the earlier net worth was 0
+	TotalAsset         float64            `json:"total_asset"`
+	LiquidAsset        float64            `json:"liquid_asset"`
+	IlliquidAsset      float64            `json:"illiquid_asset"`
+	Liabilities        float64            `json:"liabilities"`
+	AssetClasses       map[string]float64 `json:"asset_classes"`
+}
+
+type NetWorthTrend struct {
+	NetWorthSnapshot
+	MonthOverMonth *NetWorthChange `json:"month_over_month"` // nil without a snapshot for the previous month
+	YearOverYear   *NetWorthChange `json:"year_over_year"`   // nil without a snapshot for the same month last year
+}
+
+// InvestmentTransaction is one entry of the ledger of a holding, amount is the cash paid or received
+type InvestmentTransaction struct {
+	ID              int64   `json:"id"`
+	InvestmentId    int64   `json:"investment_id"`
+	Type            string  `json:"type"` // buy, sell, dividend or sip
+	TransactionDate Date    `json:"transaction_date"`
+	Amount          float64 `json:"amount"`
+	Units           float64 `json:"units"` // 0 for dividends
+}
+
+// DatedCashflow is a cash movement for XIRR, money invested is negative
+type DatedCashflow struct {
+	Date   time.Time
+	Amount float64
+}
+
+type CostBasis struct {
+	RemainingCost  float64 `json:"remaining_cost"` // cost of the units still held
+	RealizedGain   float64 `json:"realized_gain"`
+	UnrealizedGain float64 `json:"unrealized_gain"`
+}
+
+type InvestmentPerformance struct {
+	MarketValue      float64   `json:"market_value"`
+	TotalInvested    float64   `json:"total_invested"`  // buys and sip instalments
+	TotalWithdrawn   float64   `json:"total_withdrawn"` // sells
+	Dividends        float64   `json:"dividends"`
+	FIFO             CostBasis `json:"fifo"`
+	AverageCost      CostBasis `json:"average_cost"`
+	AbsoluteGain     float64   `json:"absolute_gain"`
+	CAGRInPercentage *float64  `json:"cagr_in_percentage"` // nil without an investment or a year of history to annualise
+	XIRRInPercentage *float64  `json:"xirr_in_percentage"` // nil when it does not converge
+}
+
+type HoldingPerformance struct {
+	InvestmentId   int64   `json:"investment_id"`
+	InvestmentName string  `json:"investment_name"`
+	AssetId        int64   `json:"asset_id"`
+	AssetName      string  `json:"asset_name"`
+	Units          float64 `json:"units"`
+	InvestmentPerformance
+}
+
+type AssetClassPerformance struct {
+	AssetId   int64  `json:"asset_id"`
+	AssetName string `json:"asset_name"`
+	InvestmentPerformance
+}
+
+type MutualFundScheme struct {
+	SchemeCode       string `json:"scheme_code"`
+	SchemeName       string `json:"scheme_name"`
+	ISINGrowth       string `json:"isin_growth"`
+	ISINReinvestment string `json:"isin_reinvestment"`
+	FundHouse        string `json:"fund_house"`
+	Category         string `json:"category"`
+}
+
+type MutualFundNAV struct {
+	SchemeCode string  `json:"scheme_code"`
+	NAVDate    Date    `json:"nav_date"`
+	NAV        float64 `json:"nav"`
+}
+
+// MutualFundNAVRecord is one scheme line of an amfi nav file
+type MutualFundNAVRecord struct {
+	MutualFundScheme
+	NAVDate Date
+	NAV     float64
+}
+
+type NAVImportResult struct {
+	SchemesImported     int   `json:"schemes_imported"`
+	NAVsImported        int   `json:"navs_imported"`
+	LinesSkipped        int   `json:"lines_skipped"` // scheme lines without a usable nav or date
+	InvestmentsRevalued int64 `json:"investments_revalued"`
+}
+
+// NAVImportRequest points the importer to a nav file on the server, used when the file is not uploaded
+type NAVImportRequest struct {
+	Path string `json:"path"`
+}
+
+// CashflowRule categorises imported bank transactions whose narration matches the regex pattern, lower priority is tried first
+type CashflowRule struct {
+	ID       int64  `json:"id"`
+	Pattern  string `json:"pattern"`
+	Category string `json:"category"`
+	Priority int64  `json:"priority"`
+}
+
+// BankStatementMapping tells the importer which csv columns to read, by header name or by 0 based index
+type BankStatementMapping struct {
+	DateColumn      string `json:"date_column"`
+	NarrationColumn string `json:"narration_column"`
+	DebitColumn     string `json:"debit_column"`
+	CreditColumn    string `json:"credit_column"`
+	DateFormat      string `json:"date_format"` // go layout, defaults to 02/01/2006
+	HasHeader       *bool  `json:"has_header"`  // defaults to true
+}
+
+type BankTransaction struct {
+	ID              int64   `json:"id"`
+	TransactionDate Date    `json:"transaction_date"`
+	Narration       string  `json:"narration"`
+	Amount          float64 `json:"amount"`
+	IsInflow        bool    `json:"is_inflow"`
+	Category        string  `json:"category"`
+	Fingerprint     string  `json:"-"`
+}
+
+type BankImportResult struct {
+	Imported         int        `json:"imported"`
+	Duplicates       int        `json:"duplicates"`
+	SkippedRows      []int      `json:"skipped_rows"` // 1 based csv line numbers that could not be read
+	DerivedCashflows []Cashflow `json:"derived_cashflows"`
+	ManualOverlaps   []string   `json:"manual_overlaps"` // recurring flows not derived because a manual cashflow already covers them
+}
+
+type MonthlySurplusProjection struct {
+	Month   string  `json:"month"` // yyyy-mm
+	Inflow  float64 `json:"inflow"`
+	Outflow float64 `json:"outflow"`
+	Surplus float64 `json:"surplus"`
+}
+
+type YearlySurplusProjection struct {
+	Year                  int     `json:"year"` // 1 for the first 12 months of the projection
+	Inflow                float64 `json:"inflow"`
+	Outflow               float64 `json:"outflow"`
+	Surplus               float64 `json:"surplus"`
+	AverageMonthlySurplus float64 `json:"average_monthly_surplus"`
+}
+
+// TaxSlab taxes the income between the limits, the top slab has no upper limit
+type TaxSlab struct {
+	LowerLimit       float64  `json:"lower_limit"`
+	UpperLimit       *float64 `json:"upper_limit"`
+	RateInPercentage float64  `json:"rate_in_percentage"`
+}
+
+// TaxRegimeConfig is the slab table and the rules of one regime in one financial year
+type TaxRegimeConfig struct {
+	FinancialYear     string    `json:"financial_year"` // like 2025-26
+	Regime            string    `json:"regime"`         // old or new
+	StandardDeduction float64   `json:"standard_deduction"`
+	RebateIncomeLimit float64   `json:"rebate_income_limit"` // taxable income up to which the whole tax is rebated (section 87A)
+	MarginalRelief    bool      `json:"marginal_relief"`     // tax above the rebate limit cannot exceed the income above it
+	CessInPercentage  float64   `json:"cess_in_percentage"`
+	Slabs             []TaxSlab `json:"slabs"`
+}
+
+type TaxSlabUpdateRequest struct {
+	FinancialYear string            `json:"financial_year"`
+	Regimes       []TaxRegimeConfig `json:"regimes"`
+}
+
+type TaxDeduction struct {
+	Section  string  `json:"section"`
+	Invested float64 `json:"invested"` // contributions of the tagged investments during the financial year
+	Limit    float64 `json:"limit"`
+	Claimed  float64 `json:"claimed"`
+}
+
+type TaxEstimate struct {
+	Regime                    string  `json:"regime"`
+	GrossIncome               float64 `json:"gross_income"`
+	StandardDeduction         float64 `json:"standard_deduction"`
+	Deductions                float64 `json:"deductions"`
+	TaxableIncome             float64 `json:"taxable_income"`
+	SlabTax                   float64 `json:"slab_tax"`
+	Rebate                    float64 `json:"rebate"`
+	Cess                      float64 `json:"cess"`
+	TotalTax                  float64 `json:"total_tax"`
+	EffectiveRateInPercentage float64 `json:"effective_rate_in_percentage"` // total tax over gross income
+}
+
+type AllocationTypeReturn struct {
+	AllocationTypeName string  `json:"allocation_type_name"`
+	PreTaxReturn       float64 `json:"pre_tax_return"`
+	PostTaxReturn      float64 `json:"post_tax_return"`
+}
+
+type GoalPostTaxReturn struct {
+	GoalId             int64   `json:"goal_id"`
+	GoalName           string  `json:"goal_name"`
+	YearsLeft          int64   `json:"years_left"`
+	AllocationTypeName string  `json:"allocation_type_name"` // band of today
+	PreTaxReturn       float64 `json:"pre_tax_return"`
+	PostTaxReturn      float64 `json:"post_tax_return"`
+}
+
+// TaxLot is the part of a purchase still held
+type TaxLot struct {
+	InvestmentId int64   `json:"investment_id"`
+	PurchaseDate Date    `json:"purchase_date"`
+	Units        float64 `json:"units"`
+	Cost         float64 `json:"cost"`
+}
+
+// RealisedGain is the part of a sale matched to one purchase lot
+type RealisedGain struct {
+	InvestmentId        int64   `json:"investment_id"`
+	InvestmentName      string  `json:"investment_name"`
+	AssetId             int64   `json:"asset_id"`
+	AssetName           string  `json:"asset_name"`
+	PurchaseDate        Date    `json:"purchase_date"`
+	SaleDate            Date    `json:"sale_date"`
+	Units               float64 `json:"units"`
+	Cost                float64 `json:"cost"` // indexed for long term gains of asset classes with indexation
+	SaleValue           float64 `json:"sale_value"`
+	Gain                float64 `json:"gain"`
+	Term                string  `json:"term"`                 // short or long
+	IndexationEstimated bool    `json:"indexation_estimated"` // the indexed cost uses an extrapolated cost inflation index
+}
+
+type AssetClassCapitalGains struct {
+	AssetId       int64   `json:"asset_id"`
+	AssetName     string  `json:"asset_name"`
+	ShortTermGain float64 `json:"short_term_gain"`
+	LongTermGain  float64 `json:"long_term_gain"`
+	ExemptGain    float64 `json:"exempt_gain"` // long term gain covered by the yearly exemption
+	EstimatedTax  float64 `json:"estimated_tax"`
+}
+
+// HarvestLot is a lot, or the first units of it, to sell and buy back so its long term gain uses the yearly exemption
+type HarvestLot struct {
+	InvestmentId   int64   `json:"investment_id"`
+	InvestmentName string  `json:"investment_name"`
+	AssetName      string  `json:"asset_name"`
+	PurchaseDate   Date    `json:"purchase_date"`
+	Units          float64 `json:"units"`
+	Cost           float64 `json:"cost"`
+	MarketValue    float64 `json:"market_value"`
+	Gain           float64 `json:"gain"`
+}
+
+type EmergencyFundConfig struct {
+	TargetMonths float64 `json:"target_months"`
+	TopUpGoal    bool    `json:"top_up_goal"` // keep a top priority goal for the gap
+	TopUpGoalId  *int64  `json:"top_up_goal_id"`
+}
+
+type EmergencyFundStatus struct {
+	MonthlyExpenses float64  `json:"monthly_expenses"` // outflows active today, spread per month
+	EmergencyFund   float64  `json:"emergency_fund"`   // liquid holdings marked as emergency fund
+	MonthsCovered   *float64 `json:"months_covered"`   // null when there are no expenses
+	TargetMonths    float64  `json:"target_months"`
+	TargetAmount    float64  `json:"target_amount"`
+	Gap             float64  `json:"gap"`
+	IsAdequate      bool     `json:"is_adequate"`
+	TopUpGoalId     *int64   `json:"top_up_goal_id"`
 }
@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+)
+
+func (h *Handler) CreateAssetClassHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.CreateAssetClass(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateAssetClassHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateAssetClass(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) DeleteAssetClassHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.DeleteAssetClass(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) GetAllocationTypesHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetAllocationTypes(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) CreateAllocationTypeHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.CreateAllocationType(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateAllocationTypeHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateAllocationType(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateAllocationTypeAgeBandsHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateAllocationTypeAgeBands(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) DeleteAllocationTypeHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.DeleteAllocationType(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateAllocationTypeConfigHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateAllocationTypeConfig(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) GetAllocationTypeBlendsHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetAllocationTypeBlends(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateAllocationTypeBlendHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateAllocationTypeBlend(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) GetAssetClassCorrelationsHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetAssetClassCorrelations(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateAssetClassCorrelationsHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateAssetClassCorrelations(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
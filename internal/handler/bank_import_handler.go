@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+)
+
+func (h *Handler) ImportBankStatementHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.ImportBankStatement(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) GetCashflowRulesHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetCashflowRules(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) CreateCashflowRuleHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.CreateCashflowRule(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateCashflowRuleHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateCashflowRule(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) DeleteCashflowRuleHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.DeleteCashflowRule(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+)
+
+func (h *Handler) GetCashflowsHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetCashflows(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) GetCashflowHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetCashflow(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) CreateCashflowHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.CreateCashflow(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateCashflowHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateCashflow(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) DeleteCashflowHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.DeleteCashflow(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) GetInvestingSurplusBreakdownHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetInvestingSurplusBreakdown(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) GetSurplusProjectionHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetSurplusProjection(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+)
+
+func (h *Handler) GetEmergencyFundHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetEmergencyFund(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) UpdateEmergencyFundHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.UpdateEmergencyFund(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"master-finanacial-planner/internal/entity"
 	"master-finanacial-planner/internal/helper"
 	"net/http"
@@ -9,7 +8,7 @@ import (
 
 func (h *Handler) GetAssetClassHandler(w http.ResponseWriter, r *http.Request) {
 
-	ctx := context.Background()
+	ctx := r.Context()
 	response, err := h.financeUsecases.GetAssetClass(ctx, r)
 	if err != nil {
 		rr := &entity.ApiResponse{
@@ -18,7 +17,7 @@ func (h *Handler) GetAssetClassHandler(w http.ResponseWriter, r *http.Request) {
 				Message: err.Error(),
 			},
 		}
-		helper.WriteCustomResp(w, 500, rr)
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
 	} else {
 		response.Error = nil
 		response.Success = true
@@ -29,7 +28,7 @@ func (h *Handler) GetAssetClassHandler(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) GetEffectiveReturnAllocationTypeHandler(w http.ResponseWriter, r *http.Request) {
 
-	ctx := context.Background()
+	ctx := r.Context()
 	response, err := h.financeUsecases.GetEffectiveReturnAllocationType(ctx, r)
 	if err != nil {
 		rr := &entity.ApiResponse{
@@ -38,7 +37,7 @@ func (h *Handler) GetEffectiveReturnAllocationTypeHandler(w http.ResponseWriter,
 				Message: err.Error(),
 			},
 		}
-		helper.WriteCustomResp(w, 500, rr)
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
 	} else {
 		response.Error = nil
 		response.Success = true
@@ -49,7 +48,7 @@ func (h *Handler) GetEffectiveReturnAllocationTypeHandler(w http.ResponseWriter,
 
 func (h *Handler) GetInvestingSurplusHandler(w http.ResponseWriter, r *http.Request) {
 
-	ctx := context.Background()
+	ctx := r.Context()
 	response, err := h.financeUsecases.GetInvestingSurplus(ctx, r)
 	if err != nil {
 		rr := &entity.ApiResponse{
@@ -58,7 +57,7 @@ func (h *Handler) GetInvestingSurplusHandler(w http.ResponseWriter, r *http.Requ
 				Message: err.Error(),
 			},
 		}
-		helper.WriteCustomResp(w, 500, rr)
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
 	} else {
 		response.Error = nil
 		response.Success = true
@@ -69,7 +68,7 @@ func (h *Handler) GetInvestingSurplusHandler(w http.ResponseWriter, r *http.Requ
 
 func (h *Handler) GetNetWorthHandler(w http.ResponseWriter, r *http.Request) {
 
-	ctx := context.Background()
+	ctx := r.Context()
 	response, err := h.financeUsecases.GetNetWorth(ctx, r)
 	if err != nil {
 		rr := &entity.ApiResponse{
@@ -78,7 +77,7 @@ func (h *Handler) GetNetWorthHandler(w http.ResponseWriter, r *http.Request) {
 				Message: err.Error(),
 			},
 		}
-		helper.WriteCustomResp(w, 500, rr)
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
 	} else {
 		response.Error = nil
 		response.Success = true
@@ -89,7 +88,7 @@ func (h *Handler) GetNetWorthHandler(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) SipAllocatorHandler(w http.ResponseWriter, r *http.Request) {
 
-	ctx := context.Background()
+	ctx := r.Context()
 	response, err := h.financeUsecases.SipAllocator(ctx, r)
 	if err != nil {
 		rr := &entity.ApiResponse{
@@ -98,7 +97,7 @@ func (h *Handler) SipAllocatorHandler(w http.ResponseWriter, r *http.Request) {
 				Message: err.Error(),
 			},
 		}
-		helper.WriteCustomResp(w, 500, rr)
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
 	} else {
 		response.Error = nil
 		response.Success = true
@@ -109,7 +108,7 @@ func (h *Handler) SipAllocatorHandler(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) GetInvestableAssetAllocation(w http.ResponseWriter, r *http.Request) {
 
-	ctx := context.Background()
+	ctx := r.Context()
 	response, err := h.financeUsecases.GetInvestableAssetAllocation(ctx, r)
 	if err != nil {
 		rr := &entity.ApiResponse{
@@ -118,7 +117,47 @@ func (h *Handler) GetInvestableAssetAllocation(w http.ResponseWriter, r *http.Re
 				Message: err.Error(),
 			},
 		}
-		helper.WriteCustomResp(w, 500, rr)
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) TakeNetWorthSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.TakeNetWorthSnapshot(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
+	} else {
+		response.Error = nil
+		response.Success = true
+		helper.WriteCustomResp(w, http.StatusOK, response)
+	}
+
+}
+
+func (h *Handler) GetNetWorthHistoryHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	response, err := h.financeUsecases.GetNetWorthHistory(ctx, r)
+	if err != nil {
+		rr := &entity.ApiResponse{
+			Data: nil,
+			Error: &entity.CommonErrorResponse{
+				Message: err.Error(),
+			},
+		}
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
 	} else {
 		response.Error = nil
 		response.Success = true
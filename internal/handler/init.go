@@ -10,9 +10,97 @@ type FinanceUsecase interface {
 	GetAssetClass(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
 	GetEffectiveReturnAllocationType(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
 	GetInvestingSurplus(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetSurplusProjection(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
 	GetNetWorth(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	TakeNetWorthSnapshot(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetNetWorthHistory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
 	SipAllocator(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
 	GetInvestableAssetAllocation(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// goal
+	CreateGoal(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetGoal(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateGoal(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteGoal(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	RollForwardGoals(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetGoalRollForwardHistory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// asset sub category
+	GetAssetSubCategories(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	CreateAssetSubCategory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateAssetSubCategory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteAssetSubCategory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetSubCategoryHoldings(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// cashflow
+	GetCashflows(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetCashflow(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	CreateCashflow(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateCashflow(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteCashflow(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	ImportBankStatement(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetCashflowRules(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	CreateCashflowRule(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateCashflowRule(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteCashflowRule(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetInvestingSurplusBreakdown(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// liability
+	GetLiabilities(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetLiability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	CreateLiability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateLiability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteLiability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetAmortizationSchedule(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetAmortizationSchedules(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// investment
+	GetInvestments(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetInvestment(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	CreateInvestment(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateInvestment(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteInvestment(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetInvestmentTransactions(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	CreateInvestmentTransaction(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteInvestmentTransaction(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetInvestmentPerformance(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetCapitalGainsReport(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetTaxHarvestingSuggestions(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetEmergencyFund(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateEmergencyFund(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// mutual fund nav
+	ImportMutualFundNAV(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetMutualFundNAVHistory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// admin
+	CreateAssetClass(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateAssetClass(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteAssetClass(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetAllocationTypes(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	CreateAllocationType(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateAllocationType(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateAllocationTypeAgeBands(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	DeleteAllocationType(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateAllocationTypeConfig(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetAllocationTypeBlends(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateAllocationTypeBlend(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetAssetClassCorrelations(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateAssetClassCorrelations(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetTaxEstimate(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetTaxSlabs(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	UpdateTaxSlabs(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// simulation
+	GetGoalSuccessProbability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetGlidePath(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+	GetPostTaxReturns(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// rebalancing
+	GetRebalanceTrades(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
+
+	// retirement
+	RetirementCalculator(ctx context.Context, r *http.Request) (*entity.ApiResponse, error)
 }
 
 type UserUsecases interface {
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"master-finanacial-planner/internal/entity"
 	"master-finanacial-planner/internal/helper"
 	"net/http"
@@ -9,7 +8,7 @@ import (
 
 func (h *Handler) SignUpHandler(w http.ResponseWriter, r *http.Request) {
 
-	ctx := context.Background()
+	ctx := r.Context()
 	response, err := h.userUsecases.SignUpUsecase(ctx, r)
 	if err != nil {
 		rr := &entity.ApiResponse{
@@ -18,7 +17,7 @@ func (h *Handler) SignUpHandler(w http.ResponseWriter, r *http.Request) {
 				Message: err.Error(),
 			},
 		}
-		helper.WriteCustomResp(w, 500, rr)
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
 	} else {
 		response.Error = nil
 		response.Success = true
@@ -30,7 +29,7 @@ func (h *Handler) SignUpHandler(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) SignInHandler(w http.ResponseWriter, r *http.Request) {
 
 	// calling the usecase for the business logic
-	ctx := context.Background()
+	ctx := r.Context()
 	response, err := h.userUsecases.SignInUsecase(ctx, r)
 	if err != nil {
 		rr := &entity.ApiResponse{
@@ -39,7 +38,7 @@ func (h *Handler) SignInHandler(w http.ResponseWriter, r *http.Request) {
 				Message: err.Error(),
 			},
 		}
-		helper.WriteCustomResp(w, 500, rr)
+		helper.WriteCustomResp(w, helper.GetErrorStatusCode(err), rr)
 	} else {
 		response.Error = nil
 		response.Success = true
@@ -0,0 +1,85 @@
+package helper
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// amfiFieldCount is the number of fields of a scheme line:
+// Scheme Code;ISIN Div Payout/ ISIN Growth;ISIN Div Reinvestment;Scheme Name;Net Asset Value;Date
+const amfiFieldCount = 6
+
+// ParseAMFINAVFile reads the semicolon delimited NAVAll file of amfi.
+// Besides the scheme lines the file has the header, blank lines, category headings like
+// "Open Ended Schemes(Debt Scheme - Banking and PSU Fund)" and fund house names, which apply to the scheme lines below them.
+// Scheme lines without a usable nav, like "N.A.", are counted in skipped
+func ParseAMFINAVFile(reader io.Reader) (records []entity.MutualFundNAVRecord, skipped int, err error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var category, fundHouse string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ";")
+		if len(fields) != amfiFieldCount {
+			// a heading, schemes type headings carry a bracket
+			if strings.Contains(line, "(") && strings.HasSuffix(line, ")") {
+				category = line
+			} else {
+				fundHouse = line
+			}
+			continue
+		}
+
+		schemeCode := strings.TrimSpace(fields[0])
+		if _, err := strconv.ParseInt(schemeCode, 10, 64); err != nil {
+			// the column header
+			continue
+		}
+
+		nav, navErr := strconv.ParseFloat(strings.TrimSpace(fields[4]), 64)
+		navDate, dateErr := time.Parse(constant.AMFINAVDateLayout, strings.TrimSpace(fields[5]))
+		if navErr != nil || dateErr != nil || nav <= 0 {
+			skipped++
+			continue
+		}
+
+		records = append(records, entity.MutualFundNAVRecord{
+			MutualFundScheme: entity.MutualFundScheme{
+				SchemeCode:       schemeCode,
+				SchemeName:       strings.TrimSpace(fields[3]),
+				ISINGrowth:       cleanISIN(fields[1]),
+				ISINReinvestment: cleanISIN(fields[2]),
+				FundHouse:        fundHouse,
+				Category:         category,
+			},
+			NAVDate: entity.NewDate(navDate),
+			NAV:     nav,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error reading nav file: %w", err)
+	}
+
+	return records, skipped, nil
+}
+
+// cleanISIN drops the "-" placeholder used for a missing isin
+func cleanISIN(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "-" {
+		return ""
+	}
+	return value
+}
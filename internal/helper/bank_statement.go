@@ -0,0 +1,143 @@
+package helper
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseBankStatementCSV reads the debit and credit rows of a bank statement as per the column mapping.
+// Rows which cannot be read, like opening balance or footer lines, are returned as skipped 1 based line numbers
+func ParseBankStatementCSV(reader io.Reader, mapping entity.BankStatementMapping) (transactions []entity.BankTransaction, skipped []int, err error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid csv: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil, errors.New("csv is empty")
+	}
+
+	dateFormat := mapping.DateFormat
+	if dateFormat == "" {
+		dateFormat = constant.DefaultBankStatementDateFormat
+	}
+
+	var header []string
+	firstRow := 0
+	if mapping.HasHeader == nil || *mapping.HasHeader {
+		header = rows[0]
+		firstRow = 1
+	}
+
+	columns := make(map[string]int)
+	for name, value := range map[string]string{
+		"date_column":      mapping.DateColumn,
+		"narration_column": mapping.NarrationColumn,
+		"debit_column":     mapping.DebitColumn,
+		"credit_column":    mapping.CreditColumn,
+	} {
+		index, err := columnIndex(header, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		columns[name] = index
+	}
+
+	transactions = []entity.BankTransaction{}
+	skipped = []int{}
+	for i := firstRow; i < len(rows); i++ {
+		row := rows[i]
+		cell := func(name string) string {
+			if columns[name] >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[columns[name]])
+		}
+
+		date, dateErr := time.Parse(dateFormat, cell("date_column"))
+		debit, debitErr := parseStatementAmount(cell("debit_column"))
+		credit, creditErr := parseStatementAmount(cell("credit_column"))
+		if dateErr != nil || debitErr != nil || creditErr != nil || (debit == 0 && credit == 0) {
+			skipped = append(skipped, i+1)
+			continue
+		}
+
+		transaction := entity.BankTransaction{
+			TransactionDate: entity.NewDate(date),
+			Narration:       cell("narration_column"),
+			Amount:          debit,
+		}
+		if credit > 0 {
+			transaction.Amount = credit
+			transaction.IsInflow = true
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, skipped, nil
+}
+
+// BankTransactionFingerprint identifies a statement row, occurrence tells apart identical rows of the same statement
+func BankTransactionFingerprint(transaction entity.BankTransaction, occurrence int) string {
+	key := fmt.Sprintf("%s|%s|%.2f|%t|%d",
+		transaction.TransactionDate.Format(entity.DateLayout),
+		strings.ToLower(strings.Join(strings.Fields(transaction.Narration), " ")),
+		transaction.Amount,
+		transaction.IsInflow,
+		occurrence,
+	)
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// columnIndex resolves a mapping value, a number is taken as the 0 based index, anything else as a header name
+func columnIndex(header []string, value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("is required")
+	}
+
+	if index, err := strconv.Atoi(value); err == nil {
+		if index < 0 {
+			return 0, errors.New("index cannot be negative")
+		}
+		return index, nil
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), value) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("column %q not found in the header", value)
+}
+
+// parseStatementAmount reads amounts like "1,23,456.00", an empty cell or "-" is 0
+func parseStatementAmount(value string) (float64, error) {
+	value = strings.ReplaceAll(value, ",", "")
+	if value == "" || value == "-" {
+		return 0, nil
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if amount < 0 {
+		return -amount, nil
+	}
+	return amount, nil
+}
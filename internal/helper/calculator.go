@@ -30,20 +30,20 @@ func CalculateSIPRequired(targetAmount float64, years int64, annualGrowthRate fl
 	return RoundToDecimals(sipAmount, 2)
 }
 
-func FireCalculator(currentAge, retirementAge, earlyRetirementAge int, monthlyExpense float64, inflationPercentage float64) entity.FireResponse {
+func FireCalculator(request entity.FireRequest) entity.FireResponse {
 
-	todayYearlyExpense := monthlyExpense * 12
-	retirementYearlyExpense := todayYearlyExpense * math.Pow(1+inflationPercentage/100, float64(retirementAge-currentAge))
+	todayYearlyExpense := request.MonthlyExpense * 12
+	retirementYearlyExpense := todayYearlyExpense * math.Pow(1+request.InflationPercentage/100, float64(request.RetirementAge-request.CurrentAge))
 
-	leanFire := retirementYearlyExpense * 15
-	fire := retirementYearlyExpense * 25
-	fatFire := retirementYearlyExpense * 50
+	leanFire := retirementYearlyExpense * request.LeanFireMultiplier
+	fire := retirementYearlyExpense * request.FireMultiplier
+	fatFire := retirementYearlyExpense * request.FatFireMultiplier
 
-	diff := retirementAge - earlyRetirementAge
+	diff := request.RetirementAge - request.EarlyRetirementAge
 
-	fixedGrowthRate := .10 // (percentage)/100
+	growthRate := request.GrowthRatePercentage / 100
 
-	earlyRetirementAmount := (fire) / math.Pow(1.0+fixedGrowthRate, float64(diff))
+	earlyRetirementAmount := (fire) / math.Pow(1.0+growthRate, float64(diff))
 
 	return entity.FireResponse{
 		YearlyExpense:           RoundToDecimals(todayYearlyExpense, 1),
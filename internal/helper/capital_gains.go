@@ -0,0 +1,125 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"time"
+)
+
+// PostTaxReturn is the yearly return left after capital gains tax when a lump sum is held for years and redeemed at once.
+// The gain is long term when years exceed the holding period, like IsLongTerm, its cost is then indexed when the profile allows it.
+// corpus is the redeemed amount the yearly exemption is set off against, 0 leaves the exemption out
+func PostTaxReturn(preTaxReturn float64, profile entity.CapitalGainsTaxProfile, years int64, corpus float64) float64 {
+	years = max(years, 1)
+	growth := math.Pow(1+preTaxReturn/100, float64(years))
+
+	longTerm := years*12 > profile.LTCGHoldingPeriodInMonths
+	rate := profile.STCGRateInPercentage
+	cost := 1.0
+	if longTerm {
+		rate = profile.LTCGRateInPercentage
+		if profile.Indexation {
+			cost = math.Pow(1+constant.CostInflationIndexGrowthPercentage/100, float64(years))
+		}
+	}
+
+	taxableGain := growth - cost
+	if longTerm && corpus > 0 {
+		// the exemption is in rupees, every unit invested grew to growth
+		taxableGain -= profile.LTCGExemptionLimit * growth / corpus
+	}
+	if taxableGain <= 0 || rate == 0 {
+		return preTaxReturn
+	}
+
+	postTaxGrowth := growth - taxableGain*rate/100
+	return (math.Pow(postTaxGrowth, 1/float64(years)) - 1) * 100
+}
+
+// IsLongTerm reports whether a lot bought on purchase and sold on sale was held longer than holdingPeriodInMonths
+func IsLongTerm(purchase time.Time, sale time.Time, holdingPeriodInMonths int64) bool {
+	return sale.After(purchase.AddDate(0, int(holdingPeriodInMonths), 0))
+}
+
+// IndexedCost grows the cost by the ratio of the cost inflation index of the sale year to that of the purchase year.
+// estimated is true when a year is not in the notified table, a later year is then extrapolated from the latest
+// notified index and a purchase before the base year uses the base year index
+func IndexedCost(cost float64, purchase time.Time, sale time.Time) (indexedCost float64, estimated bool) {
+	purchaseIndex, purchaseEstimated := costInflationIndexOf(purchase)
+	saleIndex, saleEstimated := costInflationIndexOf(sale)
+	return cost * saleIndex / purchaseIndex, purchaseEstimated || saleEstimated
+}
+
+// costInflationIndexOf returns the index of the financial year of the date and whether it had to be estimated
+func costInflationIndexOf(t time.Time) (float64, bool) {
+	financialYear := FinancialYearOf(t)
+	if index, ok := constant.CostInflationIndex[financialYear]; ok {
+		return index, false
+	}
+
+	var firstYear, lastYear string
+	for year := range constant.CostInflationIndex {
+		if firstYear == "" || year < firstYear {
+			firstYear = year
+		}
+		if year > lastYear {
+			lastYear = year
+		}
+	}
+	if financialYear < firstYear {
+		return constant.CostInflationIndex[firstYear], true
+	}
+
+	start, _, _ := FinancialYearRange(financialYear)
+	lastStart, _, _ := FinancialYearRange(lastYear)
+	yearsAfter := start.Year() - lastStart.Year()
+	return constant.CostInflationIndex[lastYear] * math.Pow(1+constant.CostInflationIndexGrowthPercentage/100, float64(yearsAfter)), true
+}
+
+// MatchFIFOLots matches every sell against the oldest purchases still held and returns the lots left open
+// and the gain of every matched part. transactions must be ordered by date
+func MatchFIFOLots(transactions []entity.InvestmentTransaction) ([]entity.TaxLot, []entity.RealisedGain) {
+	lots := []entity.TaxLot{}
+	realised := []entity.RealisedGain{}
+
+	for _, transaction := range transactions {
+		switch transaction.Type {
+		case constant.TransactionTypeBuy, constant.TransactionTypeSIP:
+			lots = append(lots, entity.TaxLot{
+				InvestmentId: transaction.InvestmentId,
+				PurchaseDate: transaction.TransactionDate,
+				Units:        transaction.Units,
+				Cost:         transaction.Amount,
+			})
+
+		case constant.TransactionTypeSell:
+			unitsToSell := transaction.Units
+			for unitsToSell > 0 && len(lots) > 0 {
+				units := math.Min(lots[0].Units, unitsToSell)
+				cost := lots[0].Cost * units / lots[0].Units
+				saleValue := transaction.Amount * units / transaction.Units
+
+				realised = append(realised, entity.RealisedGain{
+					InvestmentId: transaction.InvestmentId,
+					PurchaseDate: lots[0].PurchaseDate,
+					SaleDate:     transaction.TransactionDate,
+					Units:        units,
+					Cost:         cost,
+					SaleValue:    saleValue,
+					Gain:         saleValue - cost,
+				})
+
+				unitsToSell -= units
+				if units == lots[0].Units {
+					lots = lots[1:]
+					continue
+				}
+				lots[0].Units -= units
+				lots[0].Cost -= cost
+			}
+		}
+	}
+
+	return lots, realised
+}
@@ -0,0 +1,211 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPostTaxReturn(t *testing.T) {
+	tests := []struct {
+		name         string
+		preTaxReturn float64
+		profile      entity.CapitalGainsTaxProfile
+		years        int64
+		corpus       float64
+		want         float64
+	}{
+		{
+			name:         "untaxed",
+			preTaxReturn: 12,
+			profile:      entity.CapitalGainsTaxProfile{LTCGHoldingPeriodInMonths: 12},
+			years:        5,
+			want:         12,
+		},
+		{
+			name:         "short term",
+			preTaxReturn: 12,
+			profile:      entity.CapitalGainsTaxProfile{STCGRateInPercentage: 20, LTCGRateInPercentage: 12.5, LTCGHoldingPeriodInMonths: 36},
+			years:        2,
+			want:         9.705059135848426,
+		},
+		{
+			name:         "long term",
+			preTaxReturn: 12,
+			profile:      entity.CapitalGainsTaxProfile{STCGRateInPercentage: 20, LTCGRateInPercentage: 12.5, LTCGHoldingPeriodInMonths: 12},
+			years:        5,
+			want:         10.76171501155292,
+		},
+		{
+			name:         "long term with the exemption set off",
+			preTaxReturn: 12,
+			profile:      entity.CapitalGainsTaxProfile{STCGRateInPercentage: 20, LTCGRateInPercentage: 12.5, LTCGHoldingPeriodInMonths: 12, LTCGExemptionLimit: 125000},
+			years:        5,
+			corpus:       1000000,
+			want:         11.12523703599586,
+		},
+		{
+			name:         "long term with indexation",
+			preTaxReturn: 12,
+			profile:      entity.CapitalGainsTaxProfile{STCGRateInPercentage: 30, LTCGRateInPercentage: 20, LTCGHoldingPeriodInMonths: 36, Indexation: true},
+			years:        5,
+			want:         10.736198730385071,
+		},
+		{
+			name:         "loss is not taxed",
+			preTaxReturn: -5,
+			profile:      entity.CapitalGainsTaxProfile{STCGRateInPercentage: 20, LTCGRateInPercentage: 12.5, LTCGHoldingPeriodInMonths: 12},
+			years:        3,
+			want:         -5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PostTaxReturn(tt.preTaxReturn, tt.profile, tt.years, tt.corpus); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("PostTaxReturn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchFIFOLots(t *testing.T) {
+	date := func(year int, month time.Month, day int) entity.Date {
+		return entity.NewDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+	buy := func(d entity.Date, amount float64, units float64) entity.InvestmentTransaction {
+		return entity.InvestmentTransaction{InvestmentId: 1, Type: constant.TransactionTypeBuy, TransactionDate: d, Amount: amount, Units: units}
+	}
+	sell := func(d entity.Date, amount float64, units float64) entity.InvestmentTransaction {
+		return entity.InvestmentTransaction{InvestmentId: 1, Type: constant.TransactionTypeSell, TransactionDate: d, Amount: amount, Units: units}
+	}
+
+	tests := []struct {
+		name         string
+		transactions []entity.InvestmentTransaction
+		wantLots     []entity.TaxLot
+		wantRealised []entity.RealisedGain
+	}{
+		{
+			name:         "no sells",
+			transactions: []entity.InvestmentTransaction{buy(date(2023, 1, 1), 1000, 10)},
+			wantLots:     []entity.TaxLot{{InvestmentId: 1, PurchaseDate: date(2023, 1, 1), Units: 10, Cost: 1000}},
+			wantRealised: []entity.RealisedGain{},
+		},
+		{
+			name: "sell spans two lots",
+			transactions: []entity.InvestmentTransaction{
+				buy(date(2023, 1, 1), 1000, 10),
+				buy(date(2023, 6, 1), 1500, 10),
+				{InvestmentId: 1, Type: constant.TransactionTypeDividend, TransactionDate: date(2023, 9, 1), Amount: 50},
+				sell(date(2024, 1, 1), 3000, 15),
+			},
+			wantLots: []entity.TaxLot{{InvestmentId: 1, PurchaseDate: date(2023, 6, 1), Units: 5, Cost: 750}},
+			wantRealised: []entity.RealisedGain{
+				{InvestmentId: 1, PurchaseDate: date(2023, 1, 1), SaleDate: date(2024, 1, 1), Units: 10, Cost: 1000, SaleValue: 2000, Gain: 1000},
+				{InvestmentId: 1, PurchaseDate: date(2023, 6, 1), SaleDate: date(2024, 1, 1), Units: 5, Cost: 750, SaleValue: 1000, Gain: 250},
+			},
+		},
+		{
+			name: "sell at a loss empties the holding",
+			transactions: []entity.InvestmentTransaction{
+				buy(date(2023, 1, 1), 1000, 10),
+				sell(date(2023, 3, 1), 800, 10),
+			},
+			wantLots: []entity.TaxLot{},
+			wantRealised: []entity.RealisedGain{
+				{InvestmentId: 1, PurchaseDate: date(2023, 1, 1), SaleDate: date(2023, 3, 1), Units: 10, Cost: 1000, SaleValue: 800, Gain: -200},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lots, realised := MatchFIFOLots(tt.transactions)
+
+			if len(lots) != len(tt.wantLots) {
+				t.Fatalf("got %d lots, want %d", len(lots), len(tt.wantLots))
+			}
+			for i, lot := range lots {
+				want := tt.wantLots[i]
+				if !lot.PurchaseDate.Equal(want.PurchaseDate.Time) || math.Abs(lot.Units-want.Units) > 1e-9 || math.Abs(lot.Cost-want.Cost) > 1e-9 {
+					t.Errorf("lot %d = %+v, want %+v", i, lot, want)
+				}
+			}
+
+			if len(realised) != len(tt.wantRealised) {
+				t.Fatalf("got %d realised gains, want %d", len(realised), len(tt.wantRealised))
+			}
+			for i, gain := range realised {
+				want := tt.wantRealised[i]
+				if !gain.PurchaseDate.Equal(want.PurchaseDate.Time) || !gain.SaleDate.Equal(want.SaleDate.Time) ||
+					math.Abs(gain.Units-want.Units) > 1e-9 || math.Abs(gain.Cost-want.Cost) > 1e-9 ||
+					math.Abs(gain.SaleValue-want.SaleValue) > 1e-9 || math.Abs(gain.Gain-want.Gain) > 1e-9 {
+					t.Errorf("realised gain %d = %+v, want %+v", i, gain, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexedCost(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		purchase      time.Time
+		sale          time.Time
+		want          float64
+		wantEstimated bool
+	}{
+		{name: "notified years", purchase: date(2015, time.June, 1), sale: date(2024, time.June, 1), want: 363, wantEstimated: false},
+		{name: "same financial year", purchase: date(2024, time.April, 1), sale: date(2025, time.March, 31), want: 254, wantEstimated: false},
+		{name: "sale after the latest notified year", purchase: date(2015, time.June, 1), sale: date(2027, time.May, 1), want: 376 * 1.05 * 1.05, wantEstimated: true},
+		{name: "purchase before the base year", purchase: date(1999, time.January, 1), sale: date(2024, time.June, 1), want: 254 * 363.0 / 100, wantEstimated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, estimated := IndexedCost(254, tt.purchase, tt.sale)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("IndexedCost() = %v, want %v", got, tt.want)
+			}
+			if estimated != tt.wantEstimated {
+				t.Errorf("estimated = %v, want %v", estimated, tt.wantEstimated)
+			}
+		})
+	}
+}
+
+func TestPostTaxReturnUsesTheLongTermRuleOfIsLongTerm(t *testing.T) {
+	profile := entity.CapitalGainsTaxProfile{STCGRateInPercentage: 20, LTCGRateInPercentage: 12.5, LTCGHoldingPeriodInMonths: 12}
+	purchase := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		years int64
+		rate  float64
+	}{
+		{name: "held exactly the holding period", years: 1, rate: profile.STCGRateInPercentage},
+		{name: "held longer than the holding period", years: 2, rate: profile.LTCGRateInPercentage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			longTerm := IsLongTerm(purchase, purchase.AddDate(int(tt.years), 0, 0), profile.LTCGHoldingPeriodInMonths)
+			if wantLongTerm := tt.rate == profile.LTCGRateInPercentage; longTerm != wantLongTerm {
+				t.Fatalf("IsLongTerm() = %v, want %v", longTerm, wantLongTerm)
+			}
+
+			growth := math.Pow(1.12, float64(tt.years))
+			want := (math.Pow(growth-(growth-1)*tt.rate/100, 1/float64(tt.years)) - 1) * 100
+			if got := PostTaxReturn(12, profile, tt.years, 0); math.Abs(got-want) > 1e-9 {
+				t.Errorf("PostTaxReturn() = %v, want %v", got, want)
+			}
+		})
+	}
+}
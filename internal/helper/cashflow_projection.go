@@ -0,0 +1,108 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"time"
+)
+
+// monthIndex counts months from year 0, so two indexes subtract to the months between them
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+// MonthlyEquivalentAmount spreads a recurring cashflow over the months of its period, one-time cashflows count as 0
+func MonthlyEquivalentAmount(cashflow entity.Cashflow) float64 {
+	switch cashflow.Frequency {
+	case constant.CashflowFrequencyQuarterly:
+		return cashflow.Amount / 3
+	case constant.CashflowFrequencyAnnual:
+		return cashflow.Amount / 12
+	case constant.CashflowFrequencyOneTime:
+		return 0
+	default:
+		return cashflow.Amount
+	}
+}
+
+// IsCashflowActive reports whether the cashflow has started and not yet ended on the date
+func IsCashflowActive(cashflow entity.Cashflow, on time.Time) bool {
+	day := entity.NewDate(on).Time
+	if cashflow.StartDate != nil && cashflow.StartDate.After(day) {
+		return false
+	}
+	if cashflow.EndDate != nil && cashflow.EndDate.Before(day) {
+		return false
+	}
+	return true
+}
+
+// CashflowAmountInMonth returns what the cashflow pays in month, for a projection starting in the month from.
+// Payments fall every 1, 3 or 12 months counted from the start date (from the projection start when there is none),
+// and the amount grows on every anniversary after today or after the start date when it starts later
+func CashflowAmountInMonth(cashflow entity.Cashflow, from time.Time, month time.Time) float64 {
+	current := monthIndex(month)
+	anchor := monthIndex(from)
+	if cashflow.StartDate != nil {
+		anchor = monthIndex(cashflow.StartDate.Time)
+	}
+
+	if current < anchor {
+		return 0
+	}
+	if cashflow.EndDate != nil && current > monthIndex(cashflow.EndDate.Time) {
+		return 0
+	}
+
+	elapsed := current - anchor
+	switch cashflow.Frequency {
+	case constant.CashflowFrequencyQuarterly:
+		if elapsed%3 != 0 {
+			return 0
+		}
+	case constant.CashflowFrequencyAnnual:
+		if elapsed%12 != 0 {
+			return 0
+		}
+	case constant.CashflowFrequencyOneTime:
+		if elapsed != 0 {
+			return 0
+		}
+	}
+
+	// the amount is today's, so only anniversaries after the later of today and the start date grow it
+	base := max(anchor, monthIndex(from))
+	anniversaries := elapsed/12 - (base-anchor)/12
+
+	return cashflow.Amount * math.Pow(1+cashflow.GrowthRateInPercentage/100, float64(anniversaries))
+}
+
+// ProjectMonthlySurplus returns the inflow, outflow and surplus of every month starting at from
+func ProjectMonthlySurplus(cashflows []entity.Cashflow, from time.Time, months int) []entity.MonthlySurplusProjection {
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	projection := make([]entity.MonthlySurplusProjection, 0, months)
+	for i := 0; i < months; i++ {
+		month := start.AddDate(0, i, 0)
+
+		var inflow, outflow float64
+		for _, cashflow := range cashflows {
+			amount := CashflowAmountInMonth(cashflow, start, month)
+			if cashflow.IsInflow {
+				inflow += amount
+			} else {
+				outflow += amount
+			}
+		}
+
+		projection = append(projection, entity.MonthlySurplusProjection{
+			Month:   month.Format("2006-01"),
+			Inflow:  RoundToDecimals(inflow, 2),
+			Outflow: RoundToDecimals(outflow, 2),
+			Surplus: RoundToDecimals(inflow-outflow, 2),
+		})
+	}
+
+	return projection
+}
@@ -0,0 +1,56 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCashflowAmountInMonth(t *testing.T) {
+	month := func(year int, m time.Month) time.Time {
+		return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+	date := func(year int, m time.Month, day int) *entity.Date {
+		d := entity.NewDate(time.Date(year, m, day, 0, 0, 0, 0, time.UTC))
+		return &d
+	}
+	from := month(2026, time.January)
+
+	monthly := entity.Cashflow{Amount: 1000, Frequency: constant.CashflowFrequencyMonthly, GrowthRateInPercentage: 10}
+	quarterly := entity.Cashflow{Amount: 3000, Frequency: constant.CashflowFrequencyQuarterly, StartDate: date(2026, time.February, 15)}
+	annualStartedEarlier := entity.Cashflow{Amount: 1000, Frequency: constant.CashflowFrequencyAnnual, StartDate: date(2024, time.March, 1), GrowthRateInPercentage: 10}
+	oneTime := entity.Cashflow{Amount: 50000, Frequency: constant.CashflowFrequencyOneTime, StartDate: date(2026, time.April, 10)}
+	ending := entity.Cashflow{Amount: 500, Frequency: constant.CashflowFrequencyMonthly, EndDate: date(2026, time.March, 31)}
+
+	tests := []struct {
+		name     string
+		cashflow entity.Cashflow
+		month    time.Time
+		want     float64
+	}{
+		{name: "monthly in the first month", cashflow: monthly, month: month(2026, time.January), want: 1000},
+		{name: "monthly before its first anniversary", cashflow: monthly, month: month(2026, time.December), want: 1000},
+		{name: "monthly grows on the anniversary", cashflow: monthly, month: month(2027, time.January), want: 1100},
+		{name: "monthly grows every year", cashflow: monthly, month: month(2028, time.June), want: 1210},
+		{name: "quarterly before the start", cashflow: quarterly, month: month(2026, time.January), want: 0},
+		{name: "quarterly in the start month", cashflow: quarterly, month: month(2026, time.February), want: 3000},
+		{name: "quarterly between payments", cashflow: quarterly, month: month(2026, time.March), want: 0},
+		{name: "quarterly next payment", cashflow: quarterly, month: month(2026, time.May), want: 3000},
+		{name: "annual off its month", cashflow: annualStartedEarlier, month: month(2026, time.February), want: 0},
+		{name: "annual grows only on anniversaries after today", cashflow: annualStartedEarlier, month: month(2026, time.March), want: 1100},
+		{name: "one-time in its month", cashflow: oneTime, month: month(2026, time.April), want: 50000},
+		{name: "one-time after its month", cashflow: oneTime, month: month(2026, time.May), want: 0},
+		{name: "in the end month", cashflow: ending, month: month(2026, time.March), want: 500},
+		{name: "after the end date", cashflow: ending, month: month(2026, time.April), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CashflowAmountInMonth(tt.cashflow, from, tt.month); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CashflowAmountInMonth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"context"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"net/http"
+)
+
+// SetAuthUserInContext returns a copy of ctx carrying the authenticated user
+func SetAuthUserInContext(ctx context.Context, authUser entity.AuthUser) context.Context {
+	return context.WithValue(ctx, constant.AuthUserContextKey, authUser)
+}
+
+// GetUserIdFromContext returns the id of the authenticated user, or a 401 error when absent
+func GetUserIdFromContext(ctx context.Context) (int64, error) {
+	authUser, err := GetAuthUserFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return authUser.ID, nil
+}
+
+// GetAuthUserFromContext returns the authenticated user, or a 401 error when absent
+func GetAuthUserFromContext(ctx context.Context) (*entity.AuthUser, error) {
+	authUser, ok := ctx.Value(constant.AuthUserContextKey).(entity.AuthUser)
+	if !ok || authUser.ID == 0 {
+		return nil, &entity.CustomError{StatusCode: http.StatusUnauthorized, Message: "user is not authenticated"}
+	}
+	return &authUser, nil
+}
@@ -0,0 +1,56 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+)
+
+// FIFOCostBasis matches every sell against the oldest purchases still held.
+// transactions must be ordered by date, marketValue is the current value of the units still held
+func FIFOCostBasis(transactions []entity.InvestmentTransaction, marketValue float64) entity.CostBasis {
+	lots, realised := MatchFIFOLots(transactions)
+
+	var costBasis entity.CostBasis
+	for _, gain := range realised {
+		costBasis.RealizedGain += gain.Gain
+	}
+	for _, lot := range lots {
+		costBasis.RemainingCost += lot.Cost
+	}
+
+	return roundCostBasis(costBasis, marketValue)
+}
+
+// AverageCostBasis values every sell at the average cost of the units held at that time
+func AverageCostBasis(transactions []entity.InvestmentTransaction, marketValue float64) entity.CostBasis {
+	var units float64
+	var costBasis entity.CostBasis
+
+	for _, transaction := range transactions {
+		switch transaction.Type {
+		case constant.TransactionTypeBuy, constant.TransactionTypeSIP:
+			units += transaction.Units
+			costBasis.RemainingCost += transaction.Amount
+
+		case constant.TransactionTypeSell:
+			if units <= 0 {
+				continue
+			}
+			soldUnits := min(transaction.Units, units)
+			soldCost := costBasis.RemainingCost * soldUnits / units
+			costBasis.RemainingCost -= soldCost
+			units -= soldUnits
+			costBasis.RealizedGain += transaction.Amount - soldCost
+		}
+	}
+
+	return roundCostBasis(costBasis, marketValue)
+}
+
+func roundCostBasis(costBasis entity.CostBasis, marketValue float64) entity.CostBasis {
+	return entity.CostBasis{
+		RemainingCost:  RoundToDecimals(costBasis.RemainingCost, 2),
+		RealizedGain:   RoundToDecimals(costBasis.RealizedGain, 2),
+		UnrealizedGain: RoundToDecimals(marketValue-costBasis.RemainingCost, 2),
+	}
+}
@@ -0,0 +1,20 @@
+package helper
+
+import (
+	"os"
+	"strconv"
+)
+
+// EnvBool reads a boolean switch like true, 1 or false from the environment, fallback when it is unset or invalid
+func EnvBool(key string, fallback bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+	return enabled
+}
@@ -0,0 +1,47 @@
+package helper
+
+import "math"
+
+// CalculateGlidePathSIP is CalculateSIPRequired with a different annual return for every year of the horizon,
+// yearlyReturns[0] being the return of the current year. With one return for every year both give the same sip
+func CalculateGlidePathSIP(targetAmount float64, yearlyReturns []float64, stepUpPercentage float64) float64 {
+	totalMonths := len(yearlyReturns) * 12
+	if totalMonths == 0 {
+		return 0
+	}
+
+	denominator := 0.0
+	// growth of an installment from its month till the end of the horizon, built backwards
+	growth := 1.0
+
+	for i := totalMonths - 1; i >= 0; i-- {
+		currentYear := i / 12
+		monthlyRate := yearlyReturns[currentYear] / (12 * 100)
+		growth *= 1 + monthlyRate
+
+		stepUpMultiplier := math.Pow(1+stepUpPercentage/100, float64(currentYear))
+		denominator += stepUpMultiplier * growth
+	}
+
+	sipAmount := targetAmount / denominator
+
+	return RoundToDecimals(sipAmount, 2)
+}
+
+// ProjectGlidePathCorpus invests the stepped up sip at the start of every month and returns the corpus at the end of every year
+func ProjectGlidePathCorpus(monthlySIP float64, yearlyReturns []float64, stepUpPercentage float64) []float64 {
+	yearEndCorpus := make([]float64, len(yearlyReturns))
+
+	var corpus float64
+	for year, yearlyReturn := range yearlyReturns {
+		monthlyRate := yearlyReturn / (12 * 100)
+		sip := monthlySIP * math.Pow(1+stepUpPercentage/100, float64(year))
+
+		for month := 0; month < 12; month++ {
+			corpus = (corpus + sip) * (1 + monthlyRate)
+		}
+		yearEndCorpus[year] = RoundToDecimals(corpus, 2)
+	}
+
+	return yearEndCorpus
+}
@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateGlidePathSIP(t *testing.T) {
+	repeat := func(value float64, years int) []float64 {
+		returns := make([]float64, years)
+		for i := range returns {
+			returns[i] = value
+		}
+		return returns
+	}
+
+	tests := []struct {
+		name             string
+		target           float64
+		yearlyReturns    []float64
+		stepUpPercentage float64
+	}{
+		{name: "flat return", target: 1000000, yearlyReturns: repeat(12, 10)},
+		{name: "flat return with step up", target: 2500000, yearlyReturns: repeat(10, 15), stepUpPercentage: 10},
+		{name: "glide path", target: 1500000, yearlyReturns: []float64{12, 12, 11, 10, 9, 8, 7}, stepUpPercentage: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sip := CalculateGlidePathSIP(tt.target, tt.yearlyReturns, tt.stepUpPercentage)
+
+			// the sip grows into the target when invested along the same returns
+			corpus := ProjectGlidePathCorpus(sip, tt.yearlyReturns, tt.stepUpPercentage)
+			if final := corpus[len(corpus)-1]; math.Abs(final-tt.target)/tt.target > 1e-4 {
+				t.Errorf("final corpus = %v, want %v", final, tt.target)
+			}
+
+			// with one return for every year it is the plain sip
+			if tt.yearlyReturns[0] == tt.yearlyReturns[len(tt.yearlyReturns)-1] {
+				want := CalculateSIPRequired(tt.target, int64(len(tt.yearlyReturns)), tt.yearlyReturns[0], tt.stepUpPercentage)
+				if sip != want {
+					t.Errorf("CalculateGlidePathSIP() = %v, want %v", sip, want)
+				}
+			}
+		})
+	}
+
+	if sip := CalculateGlidePathSIP(100000, nil, 0); sip != 0 {
+		t.Errorf("CalculateGlidePathSIP() without a horizon = %v, want 0", sip)
+	}
+}
@@ -2,8 +2,13 @@ package helper
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi"
+	"master-finanacial-planner/internal/entity"
 	"math"
 	"net/http"
+	"strconv"
 )
 
 func WriteCustomResp(w http.ResponseWriter, headerStatus int, response interface{}) {
@@ -21,3 +26,43 @@ func RoundToDecimals(value float64, upToPlace int) float64 {
 	}
 	return math.Round(value*x) / x
 }
+
+// GetErrorStatusCode returns the status code carried by a CustomError, 500 otherwise
+func GetErrorStatusCode(err error) int {
+	var customError *entity.CustomError
+	if errors.As(err, &customError) {
+		return customError.StatusCode
+	}
+	return http.StatusInternalServerError
+}
+
+// DecodeRequestBody decodes the json body of the request into v
+func DecodeRequestBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return &entity.CustomError{StatusCode: http.StatusBadRequest, Message: "invalid request body"}
+	}
+	return nil
+}
+
+// ParseMultipartUpload caps the whole request body at maxBytes before parsing the multipart form,
+// maxMemory alone would only decide how much of it is kept in memory instead of temp files
+func ParseMultipartUpload(r *http.Request, maxBytes int64) error {
+	r.Body = http.MaxBytesReader(nil, r.Body, maxBytes)
+	if err := r.ParseMultipartForm(maxBytes); err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return &entity.CustomError{StatusCode: http.StatusRequestEntityTooLarge, Message: fmt.Sprintf("upload cannot be larger than %d MB", maxBytes>>20)}
+		}
+		return &entity.CustomError{StatusCode: http.StatusBadRequest, Message: "invalid multipart upload"}
+	}
+	return nil
+}
+
+// GetIdFromUrlParam parses the positive integer id from the chi url param
+func GetIdFromUrlParam(r *http.Request, param string) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, param), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, &entity.CustomError{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("invalid %s", param)}
+	}
+	return id, nil
+}
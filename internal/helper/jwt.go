@@ -0,0 +1,64 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"master-finanacial-planner/internal/entity"
+	"strings"
+	"time"
+)
+
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// GenerateJWT builds a HS256 signed token for the given claims
+func GenerateJWT(claims entity.JwtClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsignedToken := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsignedToken + "." + signJWT(unsignedToken), nil
+}
+
+// ParseJWT verifies the signature and expiry of the token and returns its claims
+func ParseJWT(token string) (*entity.JwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	if parts[0] != jwtHeader {
+		return nil, errors.New("unsupported token header")
+	}
+
+	expectedSignature := signJWT(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expectedSignature), []byte(parts[2])) {
+		return nil, errors.New("invalid token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed token payload")
+	}
+
+	var claims entity.JwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errors.New("malformed token payload")
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, errors.New("token has expired")
+	}
+
+	return &claims, nil
+}
+
+func signJWT(unsignedToken string) string {
+	mac := hmac.New(sha256.New, entity.JwtKey)
+	mac.Write([]byte(unsignedToken))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"time"
+)
+
+// CalculateEMI returns the equated monthly instalment for the principal, annual rate and tenure
+func CalculateEMI(principal float64, annualRate float64, tenureInMonths int) float64 {
+	if tenureInMonths <= 0 {
+		return 0
+	}
+
+	monthlyRate := annualRate / (12 * 100)
+	if monthlyRate == 0 {
+		return RoundToDecimals(principal/float64(tenureInMonths), 2)
+	}
+
+	growth := math.Pow(1+monthlyRate, float64(tenureInMonths))
+	return RoundToDecimals(principal*monthlyRate*growth/(growth-1), 2)
+}
+
+// AmortizationSchedule splits every emi into interest and principal until the loan is closed.
+// The last emi is trimmed to the remaining balance
+func AmortizationSchedule(principal float64, annualRate float64, tenureInMonths int, emi float64, startDate time.Time) []entity.AmortizationEntry {
+	monthlyRate := annualRate / (12 * 100)
+	balance := principal
+
+	schedule := []entity.AmortizationEntry{}
+	for month := 1; month <= tenureInMonths && balance > 0; month++ {
+		interest := RoundToDecimals(balance*monthlyRate, 2)
+		payment := emi
+
+		// close the loan on the last month, or earlier if the emi covers the balance
+		if month == tenureInMonths || payment >= balance+interest {
+			payment = RoundToDecimals(balance+interest, 2)
+		}
+
+		principalPaid := RoundToDecimals(payment-interest, 2)
+		closingBalance := RoundToDecimals(balance-principalPaid, 2)
+
+		schedule = append(schedule, entity.AmortizationEntry{
+			Month:          month,
+			Date:           entity.NewDate(AddMonths(startDate, month-1)),
+			OpeningBalance: RoundToDecimals(balance, 2),
+			EMI:            payment,
+			Principal:      principalPaid,
+			Interest:       interest,
+			ClosingBalance: closingBalance,
+		})
+
+		balance = closingBalance
+	}
+
+	return schedule
+}
+
+// OutstandingPrincipal returns the balance left after every emi due on or before the date
+func OutstandingPrincipal(principal float64, schedule []entity.AmortizationEntry, asOf time.Time) float64 {
+	outstanding := principal
+	for _, entry := range schedule {
+		if entry.Date.After(asOf) {
+			break
+		}
+		outstanding = entry.ClosingBalance
+	}
+	return outstanding
+}
+
+// AddMonths moves the date by the months, clamping to the last day of shorter months
+func AddMonths(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location()).AddDate(0, months, 0)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, date.Location())
+}
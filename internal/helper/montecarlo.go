@@ -0,0 +1,123 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// choleskyTolerance lets a positive semi-definite matrix through despite floating point noise
+const choleskyTolerance = 1e-9
+
+// CholeskyDecomposition returns the lower triangular L with L * L^T = matrix.
+// ok is false when the matrix is not symmetric positive semi-definite, so it cannot be a correlation matrix
+func CholeskyDecomposition(matrix [][]float64) (lower [][]float64, ok bool) {
+	n := len(matrix)
+	lower = make([][]float64, n)
+	for i := range lower {
+		lower[i] = make([]float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			if math.Abs(matrix[i][j]-matrix[j][i]) > choleskyTolerance {
+				return nil, false
+			}
+
+			sum := matrix[i][j]
+			for k := 0; k < j; k++ {
+				sum -= lower[i][k] * lower[j][k]
+			}
+
+			if i == j {
+				if sum < -choleskyTolerance {
+					return nil, false
+				}
+				lower[i][i] = math.Sqrt(math.Max(sum, 0))
+				continue
+			}
+
+			// a zero pivot means the row is fully explained by the earlier ones
+			if lower[j][j] == 0 {
+				if math.Abs(sum) > choleskyTolerance {
+					return nil, false
+				}
+				continue
+			}
+			lower[i][j] = sum / lower[j][j]
+		}
+	}
+
+	return lower, true
+}
+
+// SimulateCorpus runs the monte carlo simulation of a goal portfolio and returns the final corpus of every run, sorted ascending.
+// Each year draws correlated normal returns for every asset class, the sip is invested at the start of every month
+// and stepped up once a year, like CalculateSIPRequired assumes
+func SimulateCorpus(
+	rng *rand.Rand,
+	assets []entity.SimulationAsset,
+	cholesky [][]float64,
+	startingCorpus float64,
+	monthlySIP float64,
+	stepUpPercentage float64,
+	years int64,
+	simulations int,
+) []float64 {
+
+	results := make([]float64, simulations)
+	independent := make([]float64, len(assets))
+
+	for s := 0; s < simulations; s++ {
+		corpus := startingCorpus
+		sip := monthlySIP
+
+		for year := int64(0); year < years; year++ {
+			for i := range independent {
+				independent[i] = rng.NormFloat64()
+			}
+
+			var portfolioReturn float64
+			for i, asset := range assets {
+				var correlated float64
+				for k := 0; k <= i; k++ {
+					correlated += cholesky[i][k] * independent[k]
+				}
+				assetReturn := asset.ExpectedReturnInPercentage + asset.VolatilityInPercentage*correlated
+				portfolioReturn += assetReturn * asset.WeightInPercentage / 100
+			}
+
+			// a normal draw can go below -100%, which would flip the corpus sign
+			portfolioReturn = math.Max(portfolioReturn, -99)
+			// compounded monthly at a twelfth of the yearly return, the same as CalculateSIPRequired
+			monthlyRate := portfolioReturn / (12 * 100)
+
+			for month := 0; month < 12; month++ {
+				corpus = (corpus + sip) * (1 + monthlyRate)
+			}
+			sip *= 1 + stepUpPercentage/100
+		}
+
+		results[s] = corpus
+	}
+
+	sort.Float64s(results)
+	return results
+}
+
+// Percentile returns the p-th percentile of sorted values using linear interpolation
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
@@ -0,0 +1,142 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestCholeskyDecomposition(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		want   [][]float64
+		wantOk bool
+	}{
+		{
+			name:   "identity",
+			matrix: [][]float64{{1, 0}, {0, 1}},
+			want:   [][]float64{{1, 0}, {0, 1}},
+			wantOk: true,
+		},
+		{
+			name:   "correlated pair",
+			matrix: [][]float64{{1, 0.5}, {0.5, 1}},
+			want:   [][]float64{{1, 0}, {0.5, math.Sqrt(0.75)}},
+			wantOk: true,
+		},
+		{
+			name:   "perfectly correlated pair is semi-definite",
+			matrix: [][]float64{{1, 1}, {1, 1}},
+			want:   [][]float64{{1, 0}, {1, 0}},
+			wantOk: true,
+		},
+		{
+			name:   "not positive semi-definite",
+			matrix: [][]float64{{1, 0.9, -0.9}, {0.9, 1, 0.9}, {-0.9, 0.9, 1}},
+			wantOk: false,
+		},
+		{
+			name:   "not symmetric",
+			matrix: [][]float64{{1, 0.2}, {0.4, 1}},
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, ok := CholeskyDecomposition(tt.matrix)
+			if ok != tt.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			for i := range tt.want {
+				for j := range tt.want[i] {
+					if math.Abs(lower[i][j]-tt.want[i][j]) > 1e-9 {
+						t.Errorf("lower[%d][%d] = %v, want %v", i, j, lower[i][j], tt.want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSimulateCorpusIsReproducible(t *testing.T) {
+	assets := []entity.SimulationAsset{
+		{ExpectedReturnInPercentage: 12, VolatilityInPercentage: 18, WeightInPercentage: 70},
+		{ExpectedReturnInPercentage: 7, VolatilityInPercentage: 4, WeightInPercentage: 30},
+	}
+	cholesky, ok := CholeskyDecomposition([][]float64{{1, 0.2}, {0.2, 1}})
+	if !ok {
+		t.Fatal("correlation matrix rejected")
+	}
+
+	first := SimulateCorpus(rand.New(rand.NewSource(42)), assets, cholesky, 100000, 10000, 10, 15, 500)
+	second := SimulateCorpus(rand.New(rand.NewSource(42)), assets, cholesky, 100000, 10000, 10, 15, 500)
+	if !slices.Equal(first, second) {
+		t.Error("the same seed gave different results")
+	}
+	if !slices.IsSorted(first) {
+		t.Error("results are not sorted")
+	}
+}
+
+func TestSimulateCorpusWithoutVolatilityMatchesSIPRequired(t *testing.T) {
+	tests := []struct {
+		name             string
+		target           float64
+		years            int64
+		returnPercentage float64
+		stepUpPercentage float64
+	}{
+		{name: "flat sip", target: 1000000, years: 10, returnPercentage: 12},
+		{name: "stepped up sip", target: 5000000, years: 20, returnPercentage: 10, stepUpPercentage: 10},
+		{name: "single year", target: 120000, years: 1, returnPercentage: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sip := CalculateSIPRequired(tt.target, tt.years, tt.returnPercentage, tt.stepUpPercentage)
+			assets := []entity.SimulationAsset{{ExpectedReturnInPercentage: tt.returnPercentage, WeightInPercentage: 100}}
+
+			results := SimulateCorpus(rand.New(rand.NewSource(1)), assets, [][]float64{{1}}, 0, sip, tt.stepUpPercentage, tt.years, 3)
+			for _, corpus := range results {
+				// the sip is rounded to the paisa, so the corpus can only be off by a tiny fraction
+				if math.Abs(corpus-tt.target)/tt.target > 1e-4 {
+					t.Errorf("corpus = %v, want %v", corpus, tt.target)
+				}
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5}
+	tests := []struct {
+		name   string
+		values []float64
+		p      float64
+		want   float64
+	}{
+		{name: "median", values: sorted, p: 50, want: 3},
+		{name: "exact rank", values: sorted, p: 25, want: 2},
+		{name: "interpolated low", values: sorted, p: 10, want: 1.4},
+		{name: "interpolated high", values: sorted, p: 90, want: 4.6},
+		{name: "minimum", values: sorted, p: 0, want: 1},
+		{name: "maximum", values: sorted, p: 100, want: 5},
+		{name: "single value", values: []float64{7}, p: 90, want: 7},
+		{name: "empty", values: nil, p: 50, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Percentile(tt.values, tt.p); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Percentile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
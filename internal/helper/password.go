@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"master-finanacial-planner/internal/constant"
+)
+
+// GenerateSalt returns a random hex encoded salt for password hashing
+func GenerateSalt() (string, error) {
+	salt := make([]byte, constant.PasswordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(salt), nil
+}
+
+// HashPassword derives the password hash using PBKDF2 with HMAC-SHA256
+func HashPassword(password string, salt string) string {
+	return hex.EncodeToString(pbkdf2SHA256([]byte(password), []byte(salt), constant.PasswordHashIterations, sha256.Size))
+}
+
+// VerifyPassword compares the password against the stored hash in constant time
+func VerifyPassword(password string, salt string, passwordHash string) bool {
+	return hmac.Equal([]byte(HashPassword(password, salt)), []byte(passwordHash))
+}
+
+func pbkdf2SHA256(password, salt []byte, iterations, keyLength int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLength := prf.Size()
+	numBlocks := (keyLength + hashLength - 1) / hashLength
+
+	derivedKey := make([]byte, 0, numBlocks*hashLength)
+	blockIndex := make([]byte, 4)
+
+	for block := 1; block <= numBlocks; block++ {
+		// U1 = PRF(password, salt || INT(block))
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(blockIndex, uint32(block))
+		prf.Write(blockIndex)
+		u := prf.Sum(nil)
+
+		t := make([]byte, len(u))
+		copy(t, u)
+
+		// Ui = PRF(password, Ui-1), T = U1 ^ U2 ^ ... ^ Uc
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		derivedKey = append(derivedKey, t...)
+	}
+
+	return derivedKey[:keyLength]
+}
@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"time"
+)
+
+// FinancialYearOf returns the indian financial year of the date, like 2025-26
+func FinancialYearOf(t time.Time) string {
+	startYear := t.Year()
+	if t.Month() < constant.FinancialYearStartMonth {
+		startYear--
+	}
+	return fmt.Sprintf("%d-%02d", startYear, (startYear+1)%100)
+}
+
+// FinancialYearRange returns the first and the last day of a financial year written like 2025-26
+func FinancialYearRange(financialYear string) (start time.Time, end time.Time, err error) {
+	var startYear, endYear int
+	if _, err := fmt.Sscanf(financialYear, "%4d-%2d", &startYear, &endYear); err != nil || len(financialYear) != 7 {
+		return time.Time{}, time.Time{}, fmt.Errorf("financial year must look like 2025-26")
+	}
+	if (startYear+1)%100 != endYear {
+		return time.Time{}, time.Time{}, fmt.Errorf("financial year %s must span two consecutive years", financialYear)
+	}
+
+	start = time.Date(startYear, constant.FinancialYearStartMonth, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, -1), nil
+}
+
+// CalculateIncomeTax applies the slab table of a regime. The standard deduction is capped at the salary income,
+// the 87A rebate (with marginal relief when the regime has it) comes off the slab tax and cess is charged on the rest.
+// Surcharge on incomes above 50 lakh is not modelled
+func CalculateIncomeTax(config entity.TaxRegimeConfig, grossIncome float64, salaryIncome float64, deductions float64) entity.TaxEstimate {
+	estimate := entity.TaxEstimate{
+		Regime:            config.Regime,
+		GrossIncome:       grossIncome,
+		StandardDeduction: math.Min(config.StandardDeduction, salaryIncome),
+		Deductions:        deductions,
+	}
+	estimate.TaxableIncome = math.Max(grossIncome-estimate.StandardDeduction-deductions, 0)
+
+	for _, slab := range config.Slabs {
+		if estimate.TaxableIncome <= slab.LowerLimit {
+			continue
+		}
+		upper := estimate.TaxableIncome
+		if slab.UpperLimit != nil {
+			upper = math.Min(upper, *slab.UpperLimit)
+		}
+		estimate.SlabTax += (upper - slab.LowerLimit) * slab.RateInPercentage / 100
+	}
+
+	if estimate.TaxableIncome <= config.RebateIncomeLimit {
+		estimate.Rebate = estimate.SlabTax
+	} else if config.MarginalRelief {
+		excessIncome := estimate.TaxableIncome - config.RebateIncomeLimit
+		estimate.Rebate = math.Max(estimate.SlabTax-excessIncome, 0)
+	}
+
+	taxAfterRebate := estimate.SlabTax - estimate.Rebate
+	estimate.Cess = taxAfterRebate * config.CessInPercentage / 100
+	estimate.TotalTax = taxAfterRebate + estimate.Cess
+	if grossIncome > 0 {
+		estimate.EffectiveRateInPercentage = RoundToDecimals(estimate.TotalTax*100/grossIncome, 2)
+	}
+
+	estimate.TaxableIncome = RoundToDecimals(estimate.TaxableIncome, 2)
+	estimate.SlabTax = RoundToDecimals(estimate.SlabTax, 2)
+	estimate.Rebate = RoundToDecimals(estimate.Rebate, 2)
+	estimate.Cess = RoundToDecimals(estimate.Cess, 2)
+	estimate.TotalTax = RoundToDecimals(estimate.TotalTax, 2)
+
+	return estimate
+}
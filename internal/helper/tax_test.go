@@ -0,0 +1,103 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"testing"
+)
+
+func TestCalculateIncomeTax(t *testing.T) {
+	limit := func(value float64) *float64 { return &value }
+	// the new regime of 2025-26
+	newRegime := entity.TaxRegimeConfig{
+		FinancialYear:     "2025-26",
+		Regime:            "new",
+		StandardDeduction: 75000,
+		RebateIncomeLimit: 1200000,
+		MarginalRelief:    true,
+		CessInPercentage:  4,
+		Slabs: []entity.TaxSlab{
+			{LowerLimit: 0, UpperLimit: limit(400000), RateInPercentage: 0},
+			{LowerLimit: 400000, UpperLimit: limit(800000), RateInPercentage: 5},
+			{LowerLimit: 800000, UpperLimit: limit(1200000), RateInPercentage: 10},
+			{LowerLimit: 1200000, UpperLimit: limit(1600000), RateInPercentage: 15},
+			{LowerLimit: 1600000, UpperLimit: limit(2000000), RateInPercentage: 20},
+			{LowerLimit: 2000000, UpperLimit: limit(2400000), RateInPercentage: 25},
+			{LowerLimit: 2400000, RateInPercentage: 30},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		grossIncome   float64
+		salaryIncome  float64
+		deductions    float64
+		wantTaxable   float64
+		wantSlabTax   float64
+		wantRebate    float64
+		wantTotalTax  float64
+		wantEffective float64
+	}{
+		{
+			name:         "salary fully rebated",
+			grossIncome:  1275000,
+			salaryIncome: 1275000,
+			wantTaxable:  1200000,
+			wantSlabTax:  60000,
+			wantRebate:   60000,
+		},
+		{
+			name:          "marginal relief just above the rebate limit",
+			grossIncome:   1300000,
+			salaryIncome:  1300000,
+			wantTaxable:   1225000,
+			wantSlabTax:   63750,
+			wantRebate:    38750,
+			wantTotalTax:  26000,
+			wantEffective: 2,
+		},
+		{
+			name:          "no rebate",
+			grossIncome:   2075000,
+			salaryIncome:  2075000,
+			wantTaxable:   2000000,
+			wantSlabTax:   200000,
+			wantTotalTax:  208000,
+			wantEffective: 10.02,
+		},
+		{
+			name:          "standard deduction only against salary",
+			grossIncome:   1275000,
+			wantTaxable:   1275000,
+			wantSlabTax:   71250,
+			wantTotalTax:  74100,
+			wantEffective: 5.81,
+		},
+		{
+			name:         "deductions cannot make income negative",
+			grossIncome:  50000,
+			salaryIncome: 50000,
+			deductions:   150000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateIncomeTax(newRegime, tt.grossIncome, tt.salaryIncome, tt.deductions)
+			if got.TaxableIncome != tt.wantTaxable {
+				t.Errorf("TaxableIncome = %v, want %v", got.TaxableIncome, tt.wantTaxable)
+			}
+			if got.SlabTax != tt.wantSlabTax {
+				t.Errorf("SlabTax = %v, want %v", got.SlabTax, tt.wantSlabTax)
+			}
+			if got.Rebate != tt.wantRebate {
+				t.Errorf("Rebate = %v, want %v", got.Rebate, tt.wantRebate)
+			}
+			if got.TotalTax != tt.wantTotalTax {
+				t.Errorf("TotalTax = %v, want %v", got.TotalTax, tt.wantTotalTax)
+			}
+			if got.EffectiveRateInPercentage != tt.wantEffective {
+				t.Errorf("EffectiveRateInPercentage = %v, want %v", got.EffectiveRateInPercentage, tt.wantEffective)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"math"
+)
+
+const (
+	xirrMaxIterations = 100
+	xirrTolerance     = 1e-7
+	daysInYear        = 365.0
+)
+
+// XIRR returns the annual rate, in percentage, at which the dated cashflows have a net present value of 0.
+// ok is false when the cashflows do not have both a negative and a positive amount, or no rate is found
+func XIRR(cashflows []entity.DatedCashflow) (rate float64, ok bool) {
+	if len(cashflows) < 2 {
+		return 0, false
+	}
+
+	var hasNegative, hasPositive bool
+	start := cashflows[0].Date
+	for _, cashflow := range cashflows {
+		hasNegative = hasNegative || cashflow.Amount < 0
+		hasPositive = hasPositive || cashflow.Amount > 0
+		if cashflow.Date.Before(start) {
+			start = cashflow.Date
+		}
+	}
+	if !hasNegative || !hasPositive {
+		return 0, false
+	}
+
+	npv := func(rate float64) float64 {
+		var value float64
+		for _, cashflow := range cashflows {
+			years := cashflow.Date.Sub(start).Hours() / 24 / daysInYear
+			value += cashflow.Amount / math.Pow(1+rate, years)
+		}
+		return value
+	}
+
+	// newton raphson from 10%, falling back to bisection when it leaves the valid range
+	guess := 0.1
+	for i := 0; i < xirrMaxIterations; i++ {
+		value := npv(guess)
+		if math.Abs(value) < xirrTolerance {
+			return guess * 100, true
+		}
+
+		var derivative float64
+		for _, cashflow := range cashflows {
+			years := cashflow.Date.Sub(start).Hours() / 24 / daysInYear
+			derivative -= years * cashflow.Amount / math.Pow(1+guess, years+1)
+		}
+		if derivative == 0 {
+			break
+		}
+
+		next := guess - value/derivative
+		if next <= -1 || math.IsNaN(next) || math.IsInf(next, 0) {
+			break
+		}
+		if math.Abs(next-guess) < xirrTolerance {
+			return next * 100, true
+		}
+		guess = next
+	}
+
+	low, high := -0.9999, 10.0
+	lowValue, highValue := npv(low), npv(high)
+	if lowValue*highValue > 0 {
+		return 0, false
+	}
+	for i := 0; i < xirrMaxIterations*10; i++ {
+		mid := (low + high) / 2
+		midValue := npv(mid)
+		if math.Abs(midValue) < xirrTolerance || high-low < xirrTolerance {
+			return mid * 100, true
+		}
+		if lowValue*midValue < 0 {
+			high = mid
+		} else {
+			low, lowValue = mid, midValue
+		}
+	}
+
+	return 0, false
+}
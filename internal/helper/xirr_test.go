@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestXIRR(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		cashflows []entity.DatedCashflow
+		want      float64
+		wantOk    bool
+	}{
+		{
+			name: "one year lump sum",
+			cashflows: []entity.DatedCashflow{
+				{Date: day(2023, time.January, 1), Amount: -1000},
+				{Date: day(2024, time.January, 1), Amount: 1100},
+			},
+			want:   10,
+			wantOk: true,
+		},
+		{
+			name: "loss",
+			cashflows: []entity.DatedCashflow{
+				{Date: day(2023, time.January, 1), Amount: -1000},
+				{Date: day(2024, time.January, 1), Amount: 800},
+			},
+			want:   -20,
+			wantOk: true,
+		},
+		{
+			name: "unordered instalments",
+			cashflows: []entity.DatedCashflow{
+				{Date: day(2024, time.January, 1), Amount: -1000},
+				{Date: day(2025, time.January, 1), Amount: 2310},
+				{Date: day(2023, time.January, 1), Amount: -1000},
+			},
+			want:   10,
+			wantOk: true,
+		},
+		{
+			name: "only investments",
+			cashflows: []entity.DatedCashflow{
+				{Date: day(2023, time.January, 1), Amount: -1000},
+				{Date: day(2024, time.January, 1), Amount: -1000},
+			},
+			wantOk: false,
+		},
+		{
+			name:      "single cashflow",
+			cashflows: []entity.DatedCashflow{{Date: day(2023, time.January, 1), Amount: -1000}},
+			wantOk:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := XIRR(tt.cashflows)
+			if ok != tt.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOk)
+			}
+			// 2024 is a leap year, so a calendar year is a day longer than the 365 days XIRR counts
+			if ok && math.Abs(got-tt.want) > 0.05 {
+				t.Errorf("XIRR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"strings"
+)
+
+// Authenticate validates the bearer token and puts the user identity into the request context
+func Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		authHeader := r.Header.Get("Authorization")
+		token, found := strings.CutPrefix(authHeader, "Bearer ")
+		if !found || strings.TrimSpace(token) == "" {
+			writeUnauthorized(w, "missing bearer token")
+			return
+		}
+
+		claims, err := helper.ParseJWT(strings.TrimSpace(token))
+		if err != nil {
+			writeUnauthorized(w, err.Error())
+			return
+		}
+
+		ctx := helper.SetAuthUserInContext(r.Context(), entity.AuthUser{
+			ID:      claims.UserId,
+			Email:   claims.Email,
+			IsAdmin: claims.IsAdmin,
+		})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	rr := &entity.ApiResponse{
+		Data: nil,
+		Error: &entity.CommonErrorResponse{
+			Message: message,
+		},
+	}
+	helper.WriteCustomResp(w, http.StatusUnauthorized, rr)
+}
+
+// RequireAdmin rejects non admin users, it must run after Authenticate
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		authUser, err := helper.GetAuthUserFromContext(r.Context())
+		if err != nil {
+			writeUnauthorized(w, err.Error())
+			return
+		}
+
+		if !authUser.IsAdmin {
+			rr := &entity.ApiResponse{
+				Data: nil,
+				Error: &entity.CommonErrorResponse{
+					Message: "admin access required",
+				},
+			}
+			helper.WriteCustomResp(w, http.StatusForbidden, rr)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
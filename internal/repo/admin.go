@@ -0,0 +1,311 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+	"net/http"
+)
+
+func (r *ResourceRepository) CreateAssetClass(ctx context.Context, assetClass entity.AssetClass) (*entity.AssetClass, error) {
+	query := `INSERT INTO asset_class (name, expected_return_in_percentage, volatility_in_percentage,
+									   stcg_rate_in_percentage, ltcg_rate_in_percentage, ltcg_holding_period_in_months, ltcg_exemption_limit, indexation)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		assetClass.Name,
+		assetClass.ExpectedReturnInPercentage,
+		assetClass.VolatilityInPercentage,
+		assetClass.STCGRateInPercentage,
+		assetClass.LTCGRateInPercentage,
+		assetClass.LTCGHoldingPeriodInMonths,
+		assetClass.LTCGExemptionLimit,
+		assetClass.Indexation,
+	).Scan(&assetClass.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating asset class: %v", err))
+		return nil, fmt.Errorf("error creating asset class: %w", err)
+	}
+
+	return &assetClass, nil
+}
+
+// UpdateAssetClass returns false when the asset class does not exist
+func (r *ResourceRepository) UpdateAssetClass(ctx context.Context, assetClass entity.AssetClass) (bool, error) {
+	query := `UPDATE asset_class
+			  SET
+				name = $1,
+				expected_return_in_percentage = $2,
+				volatility_in_percentage = $3,
+				stcg_rate_in_percentage = $4,
+				ltcg_rate_in_percentage = $5,
+				ltcg_holding_period_in_months = $6,
+				ltcg_exemption_limit = $7,
+				indexation = $8
+			  WHERE id = $9`
+
+	result, err := r.db.ExecContext(ctx, query,
+		assetClass.Name,
+		assetClass.ExpectedReturnInPercentage,
+		assetClass.VolatilityInPercentage,
+		assetClass.STCGRateInPercentage,
+		assetClass.LTCGRateInPercentage,
+		assetClass.LTCGHoldingPeriodInMonths,
+		assetClass.LTCGExemptionLimit,
+		assetClass.Indexation,
+		assetClass.ID,
+	)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error updating asset class: %v", err))
+		return false, fmt.Errorf("error updating asset class: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error updating asset class: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteAssetClass returns false when the asset class does not exist
+func (r *ResourceRepository) DeleteAssetClass(ctx context.Context, assetClassId int64) (bool, error) {
+	query := `DELETE FROM asset_class WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, assetClassId)
+	if isForeignKeyViolation(err) {
+		return false, &entity.CustomError{StatusCode: http.StatusConflict, Message: "asset class is still used by allocation configs, sub categories or investments"}
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting asset class: %v", err))
+		return false, fmt.Errorf("error deleting asset class: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting asset class: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetAllocationTypes returns every allocation type ordered by its age band
+func (r *ResourceRepository) GetAllocationTypes(ctx context.Context) ([]entity.AllocationType, error) {
+	query := `SELECT
+				id,
+				name,
+				COALESCE(description, ''),
+				min_age,
+				max_age
+			  FROM allocation_type
+			  ORDER BY min_age, id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying allocation types: %w", err)
+	}
+	defer rows.Close()
+
+	allocationTypes := []entity.AllocationType{}
+	for rows.Next() {
+		var allocationType entity.AllocationType
+		if err := rows.Scan(
+			&allocationType.ID,
+			&allocationType.Name,
+			&allocationType.Description,
+			&allocationType.MinAge,
+			&allocationType.MaxAge,
+		); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning allocation type row: %w", err)
+		}
+		allocationTypes = append(allocationTypes, allocationType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return allocationTypes, nil
+}
+
+// CreateAllocationType inserts the allocation type together with its config rows
+func (r *ResourceRepository) CreateAllocationType(ctx context.Context, allocationType entity.AllocationType, config []entity.AllocationTypeConfig) (*entity.AllocationType, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting allocation type creation: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO allocation_type (name, description, min_age, max_age)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id`
+
+	err = tx.QueryRowContext(ctx, query,
+		allocationType.Name,
+		allocationType.Description,
+		allocationType.MinAge,
+		allocationType.MaxAge,
+	).Scan(&allocationType.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating allocation type: %v", err))
+		return nil, fmt.Errorf("error creating allocation type: %w", err)
+	}
+
+	if err := insertAllocationTypeConfig(ctx, tx, allocationType.ID, config); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing allocation type creation: %w", err)
+	}
+
+	return &allocationType, nil
+}
+
+// UpdateAllocationTypes updates the given allocation types in one transaction, so age bands can be moved together.
+// Returns false when any of them does not exist
+func (r *ResourceRepository) UpdateAllocationTypes(ctx context.Context, allocationTypes []entity.AllocationType) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting allocation type update: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE allocation_type
+			  SET
+				name = $1,
+				description = $2,
+				min_age = $3,
+				max_age = $4
+			  WHERE id = $5`
+
+	for _, allocationType := range allocationTypes {
+		result, err := tx.ExecContext(ctx, query,
+			allocationType.Name,
+			allocationType.Description,
+			allocationType.MinAge,
+			allocationType.MaxAge,
+			allocationType.ID,
+		)
+		if err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error updating allocation type: %v", err))
+			return false, fmt.Errorf("error updating allocation type: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return false, fmt.Errorf("error updating allocation type: %w", err)
+		}
+		if rowsAffected == 0 {
+			return false, nil
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing allocation type update: %w", err)
+	}
+
+	return true, nil
+}
+
+// DeleteAllocationType removes the allocation type with its config and blend rows, returns false when it does not exist
+func (r *ResourceRepository) DeleteAllocationType(ctx context.Context, allocationTypeId int64) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting allocation type deletion: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM allocation_type_config WHERE allocation_type_id = $1`, allocationTypeId); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting allocation type config: %v", err))
+		return false, fmt.Errorf("error deleting allocation type config: %w", err)
+	}
+
+	// dropping the type from another blend would silently change that type's return, so those blends are edited first
+	var blendedInto string
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(string_agg(DISTINCT at.name, ', '), '')
+		FROM allocation_type_blend atb
+		JOIN allocation_type at ON at.id = atb.allocation_type_id
+		WHERE atb.component_allocation_type_id = $1 AND atb.allocation_type_id <> $1`, allocationTypeId).Scan(&blendedInto)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error checking allocation type blends: %v", err))
+		return false, fmt.Errorf("error checking allocation type blends: %w", err)
+	}
+	if blendedInto != "" {
+		return false, &entity.CustomError{
+			StatusCode: http.StatusConflict,
+			Message:    fmt.Sprintf("allocation type is a blend component of %s, remove it from those blends first", blendedInto),
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM allocation_type_blend WHERE allocation_type_id = $1`, allocationTypeId); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting allocation type blend: %v", err))
+		return false, fmt.Errorf("error deleting allocation type blend: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM allocation_type WHERE id = $1`, allocationTypeId)
+	if isForeignKeyViolation(err) {
+		return false, &entity.CustomError{StatusCode: http.StatusConflict, Message: "allocation type is still referenced"}
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting allocation type: %v", err))
+		return false, fmt.Errorf("error deleting allocation type: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting allocation type: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing allocation type deletion: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReplaceAllocationTypeConfig swaps all config rows of the allocation type in one transaction
+func (r *ResourceRepository) ReplaceAllocationTypeConfig(ctx context.Context, allocationTypeId int64, config []entity.AllocationTypeConfig) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting allocation type config update: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM allocation_type_config WHERE allocation_type_id = $1`, allocationTypeId); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting allocation type config: %v", err))
+		return fmt.Errorf("error deleting allocation type config: %w", err)
+	}
+
+	if err := insertAllocationTypeConfig(ctx, tx, allocationTypeId, config); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing allocation type config update: %w", err)
+	}
+
+	return nil
+}
+
+func insertAllocationTypeConfig(ctx context.Context, tx *sql.Tx, allocationTypeId int64, config []entity.AllocationTypeConfig) error {
+	query := `INSERT INTO allocation_type_config (allocation_type_id, asset_class_id, allocation_in_percentage)
+			  VALUES ($1, $2, $3)`
+
+	for _, row := range config {
+		if _, err := tx.ExecContext(ctx, query, allocationTypeId, row.AssetId, row.AllocationInPercentage); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error inserting allocation type config: %v", err))
+			return fmt.Errorf("error inserting allocation type config: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+	"time"
+)
+
+// GetCashflowRules returns the rules of the user in the order they are tried
+func (r *ResourceRepository) GetCashflowRules(ctx context.Context, userId int64) ([]entity.CashflowRule, error) {
+	query := `SELECT id, pattern, category, priority
+			  FROM cashflow_category_rule
+			  WHERE user_id = $1
+			  ORDER BY priority, id`
+
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying cashflow rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []entity.CashflowRule{}
+	for rows.Next() {
+		var rule entity.CashflowRule
+		if err := rows.Scan(&rule.ID, &rule.Pattern, &rule.Category, &rule.Priority); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning cashflow rule row: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *ResourceRepository) CreateCashflowRule(ctx context.Context, userId int64, rule entity.CashflowRule) (*entity.CashflowRule, error) {
+	query := `INSERT INTO cashflow_category_rule (user_id, pattern, category, priority)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, userId, rule.Pattern, rule.Category, rule.Priority).Scan(&rule.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating cashflow rule: %v", err))
+		return nil, fmt.Errorf("error creating cashflow rule: %w", err)
+	}
+
+	return &rule, nil
+}
+
+// UpdateCashflowRule returns false when the rule does not exist for the user
+func (r *ResourceRepository) UpdateCashflowRule(ctx context.Context, userId int64, rule entity.CashflowRule) (bool, error) {
+	query := `UPDATE cashflow_category_rule
+			  SET
+				pattern = $1,
+				category = $2,
+				priority = $3
+			  WHERE id = $4 AND user_id = $5`
+
+	result, err := r.db.ExecContext(ctx, query, rule.Pattern, rule.Category, rule.Priority, rule.ID, userId)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error updating cashflow rule: %v", err))
+		return false, fmt.Errorf("error updating cashflow rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error updating cashflow rule: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteCashflowRule returns false when the rule does not exist for the user
+func (r *ResourceRepository) DeleteCashflowRule(ctx context.Context, userId int64, ruleId int64) (bool, error) {
+	query := `DELETE FROM cashflow_category_rule WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, ruleId, userId)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting cashflow rule: %v", err))
+		return false, fmt.Errorf("error deleting cashflow rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting cashflow rule: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CreateBankTransactions stores the statement rows, rows whose fingerprint is already stored are counted as duplicates
+func (r *ResourceRepository) CreateBankTransactions(ctx context.Context, userId int64, transactions []entity.BankTransaction) (imported int, duplicates int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error starting bank transaction import: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO bank_transaction (user_id, transaction_date, narration, amount, is_inflow, category, fingerprint)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  ON CONFLICT (user_id, fingerprint) DO NOTHING
+			  RETURNING id`
+
+	for _, transaction := range transactions {
+		var id int64
+		err := tx.QueryRowContext(ctx, query,
+			userId,
+			transaction.TransactionDate,
+			transaction.Narration,
+			transaction.Amount,
+			transaction.IsInflow,
+			transaction.Category,
+			transaction.Fingerprint,
+		).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			duplicates++
+			continue
+		}
+		if err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error importing bank transaction: %v", err))
+			return 0, 0, fmt.Errorf("error importing bank transaction: %w", err)
+		}
+		imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("error committing bank transaction import: %w", err)
+	}
+
+	return imported, duplicates, nil
+}
+
+// GetBankTransactions returns the imported transactions on or after since, oldest first
+func (r *ResourceRepository) GetBankTransactions(ctx context.Context, userId int64, since time.Time) ([]entity.BankTransaction, error) {
+	query := `SELECT id, transaction_date, narration, amount, is_inflow, category
+			  FROM bank_transaction
+			  WHERE user_id = $1 AND transaction_date >= $2
+			  ORDER BY transaction_date, id`
+
+	rows, err := r.db.QueryContext(ctx, query, userId, since)
+	if err != nil {
+		return nil, fmt.Errorf("error querying bank transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []entity.BankTransaction{}
+	for rows.Next() {
+		var transaction entity.BankTransaction
+		if err := rows.Scan(
+			&transaction.ID,
+			&transaction.TransactionDate,
+			&transaction.Narration,
+			&transaction.Amount,
+			&transaction.IsInflow,
+			&transaction.Category,
+		); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning bank transaction row: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// ReplaceBankImportCashflows swaps the cashflows derived from bank statements, manual cashflows are left alone
+func (r *ResourceRepository) ReplaceBankImportCashflows(ctx context.Context, userId int64, cashflows []entity.Cashflow) ([]entity.Cashflow, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting derived cashflow update: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cashflow WHERE user_id = $1 AND source = $2`, userId, constant.CashflowSourceBankImport); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting derived cashflows: %v", err))
+		return nil, fmt.Errorf("error deleting derived cashflows: %w", err)
+	}
+
+	query := `INSERT INTO cashflow (user_id, name, amount, is_inflow, category, source, frequency)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  RETURNING id`
+
+	saved := []entity.Cashflow{}
+	for _, cashflow := range cashflows {
+		cashflow.Source = constant.CashflowSourceBankImport
+		if err := tx.QueryRowContext(ctx, query, userId, cashflow.Name, cashflow.Amount, cashflow.IsInflow, cashflow.Category, cashflow.Source, cashflow.Frequency).Scan(&cashflow.ID); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error inserting derived cashflow: %v", err))
+			return nil, fmt.Errorf("error inserting derived cashflow: %w", err)
+		}
+		saved = append(saved, cashflow)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing derived cashflow update: %w", err)
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+func (r *ResourceRepository) GetAllocationTypeBlends(ctx context.Context) ([]entity.AllocationTypeBlend, error) {
+	query := `SELECT
+				atb.allocation_type_id,
+				at.name AS allocation_type_name,
+				atb.component_allocation_type_id,
+				component.name AS component_allocation_type_name,
+				atb.weight_in_percentage
+			  FROM allocation_type_blend atb
+			  JOIN allocation_type at
+				ON atb.allocation_type_id = at.id
+			  JOIN allocation_type component
+				ON atb.component_allocation_type_id = component.id
+			  ORDER BY atb.allocation_type_id, atb.component_allocation_type_id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying allocation type blends: %w", err)
+	}
+	defer rows.Close()
+
+	blends := []entity.AllocationTypeBlend{}
+	for rows.Next() {
+		var blend entity.AllocationTypeBlend
+		if err := rows.Scan(
+			&blend.AllocationTypeId,
+			&blend.AllocationTypeName,
+			&blend.ComponentAllocationTypeId,
+			&blend.ComponentAllocationTypeName,
+			&blend.WeightInPercentage,
+		); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning allocation type blend row: %w", err)
+		}
+		blends = append(blends, blend)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return blends, nil
+}
+
+// ReplaceAllocationTypeBlend swaps the blend of the allocation type, an empty blend removes it
+func (r *ResourceRepository) ReplaceAllocationTypeBlend(ctx context.Context, allocationTypeId int64, blends []entity.AllocationTypeBlend) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting allocation type blend update: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM allocation_type_blend WHERE allocation_type_id = $1`, allocationTypeId); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting allocation type blend: %v", err))
+		return fmt.Errorf("error deleting allocation type blend: %w", err)
+	}
+
+	query := `INSERT INTO allocation_type_blend (allocation_type_id, component_allocation_type_id, weight_in_percentage)
+			  VALUES ($1, $2, $3)`
+
+	for _, blend := range blends {
+		if _, err := tx.ExecContext(ctx, query, allocationTypeId, blend.ComponentAllocationTypeId, blend.WeightInPercentage); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error inserting allocation type blend: %v", err))
+			return fmt.Errorf("error inserting allocation type blend: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing allocation type blend update: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+// cashflowColumns must stay in sync with scanCashflow
+const cashflowColumns = `
+				id,
+				name,
+				amount,
+				is_inflow,
+				category,
+				source,
+				frequency,
+				start_date,
+				end_date,
+				growth_rate_in_percentage`
+
+func scanCashflow(row interface{ Scan(dest ...any) error }) (entity.Cashflow, error) {
+	var cashflow entity.Cashflow
+	err := row.Scan(
+		&cashflow.ID,
+		&cashflow.Name,
+		&cashflow.Amount,
+		&cashflow.IsInflow,
+		&cashflow.Category,
+		&cashflow.Source,
+		&cashflow.Frequency,
+		&cashflow.StartDate,
+		&cashflow.EndDate,
+		&cashflow.GrowthRateInPercentage,
+	)
+	return cashflow, err
+}
+
+func (r *ResourceRepository) GetCashflows(ctx context.Context, userId int64) ([]entity.Cashflow, error) {
+	query := `SELECT ` + cashflowColumns + `
+			  FROM cashflow
+			  WHERE user_id = $1
+			  ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying cashflow data: %w", err)
+	}
+	defer rows.Close()
+
+	cashflows := []entity.Cashflow{}
+	for rows.Next() {
+		cashflow, err := scanCashflow(rows)
+		if err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning cashflow row: %w", err)
+		}
+		cashflows = append(cashflows, cashflow)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return cashflows, nil
+}
+
+// GetCashflowById returns nil when the cashflow does not exist for the user
+func (r *ResourceRepository) GetCashflowById(ctx context.Context, userId int64, cashflowId int64) (*entity.Cashflow, error) {
+	query := `SELECT ` + cashflowColumns + `
+			  FROM cashflow
+			  WHERE id = $1 AND user_id = $2`
+
+	cashflow, err := scanCashflow(r.db.QueryRowContext(ctx, query, cashflowId, userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying cashflow: %v", err))
+		return nil, fmt.Errorf("error querying cashflow: %w", err)
+	}
+
+	return &cashflow, nil
+}
+
+func (r *ResourceRepository) CreateCashflow(ctx context.Context, userId int64, cashflow entity.Cashflow) (*entity.Cashflow, error) {
+	query := `INSERT INTO cashflow (user_id, name, amount, is_inflow, category, source, frequency, start_date, end_date, growth_rate_in_percentage)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			  RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		userId,
+		cashflow.Name,
+		cashflow.Amount,
+		cashflow.IsInflow,
+		cashflow.Category,
+		cashflow.Source,
+		cashflow.Frequency,
+		cashflow.StartDate,
+		cashflow.EndDate,
+		cashflow.GrowthRateInPercentage,
+	).Scan(&cashflow.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating cashflow: %v", err))
+		return nil, fmt.Errorf("error creating cashflow: %w", err)
+	}
+
+	return &cashflow, nil
+}
+
+// UpdateCashflow returns false when the cashflow does not exist for the user
+func (r *ResourceRepository) UpdateCashflow(ctx context.Context, userId int64, cashflow entity.Cashflow) (bool, error) {
+	query := `UPDATE cashflow
+			  SET
+				name = $1,
+				amount = $2,
+				is_inflow = $3,
+				category = $4,
+				source = $5,
+				frequency = $6,
+				start_date = $7,
+				end_date = $8,
+				growth_rate_in_percentage = $9
+			  WHERE id = $10 AND user_id = $11`
+
+	result, err := r.db.ExecContext(ctx, query,
+		cashflow.Name,
+		cashflow.Amount,
+		cashflow.IsInflow,
+		cashflow.Category,
+		cashflow.Source,
+		cashflow.Frequency,
+		cashflow.StartDate,
+		cashflow.EndDate,
+		cashflow.GrowthRateInPercentage,
+		cashflow.ID,
+		userId,
+	)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error updating cashflow: %v", err))
+		return false, fmt.Errorf("error updating cashflow: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error updating cashflow: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteCashflow returns false when the cashflow does not exist for the user
+func (r *ResourceRepository) DeleteCashflow(ctx context.Context, userId int64, cashflowId int64) (bool, error) {
+	query := `DELETE FROM cashflow WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, cashflowId, userId)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting cashflow: %v", err))
+		return false, fmt.Errorf("error deleting cashflow: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting cashflow: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+func (r *ResourceRepository) GetAssetClassCorrelations(ctx context.Context) ([]entity.AssetClassCorrelation, error) {
+	query := `SELECT asset_class_id_a, asset_class_id_b, correlation
+			  FROM asset_class_correlation
+			  ORDER BY asset_class_id_a, asset_class_id_b`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying asset class correlations: %w", err)
+	}
+	defer rows.Close()
+
+	correlations := []entity.AssetClassCorrelation{}
+	for rows.Next() {
+		var correlation entity.AssetClassCorrelation
+		if err := rows.Scan(&correlation.AssetClassIdA, &correlation.AssetClassIdB, &correlation.Correlation); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning asset class correlation row: %w", err)
+		}
+		correlations = append(correlations, correlation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return correlations, nil
+}
+
+// ReplaceAssetClassCorrelations swaps the whole correlation matrix in one transaction
+func (r *ResourceRepository) ReplaceAssetClassCorrelations(ctx context.Context, correlations []entity.AssetClassCorrelation) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting asset class correlation update: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM asset_class_correlation`); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting asset class correlations: %v", err))
+		return fmt.Errorf("error deleting asset class correlations: %w", err)
+	}
+
+	query := `INSERT INTO asset_class_correlation (asset_class_id_a, asset_class_id_b, correlation)
+			  VALUES ($1, $2, $3)`
+
+	for _, correlation := range correlations {
+		if _, err := tx.ExecContext(ctx, query, correlation.AssetClassIdA, correlation.AssetClassIdB, correlation.Correlation); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error inserting asset class correlation: %v", err))
+			return fmt.Errorf("error inserting asset class correlation: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing asset class correlation update: %w", err)
+	}
+
+	return nil
+}
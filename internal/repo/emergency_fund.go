@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+// GetEmergencyFundConfig returns nil when the user has not configured the emergency fund
+func (r *ResourceRepository) GetEmergencyFundConfig(ctx context.Context, userId int64) (*entity.EmergencyFundConfig, error) {
+	query := `SELECT target_months, top_up_goal, top_up_goal_id
+			  FROM emergency_fund_config
+			  WHERE user_id = $1`
+
+	var config entity.EmergencyFundConfig
+	err := r.db.QueryRowContext(ctx, query, userId).Scan(&config.TargetMonths, &config.TopUpGoal, &config.TopUpGoalId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying emergency fund config: %v", err))
+		return nil, fmt.Errorf("error querying emergency fund config: %w", err)
+	}
+
+	return &config, nil
+}
+
+func (r *ResourceRepository) SaveEmergencyFundConfig(ctx context.Context, userId int64, config entity.EmergencyFundConfig) error {
+	query := `INSERT INTO emergency_fund_config (user_id, target_months, top_up_goal, top_up_goal_id)
+			  VALUES ($1, $2, $3, $4)
+			  ON CONFLICT (user_id) DO UPDATE
+			  SET
+				target_months = EXCLUDED.target_months,
+				top_up_goal = EXCLUDED.top_up_goal,
+				top_up_goal_id = EXCLUDED.top_up_goal_id`
+
+	if _, err := r.db.ExecContext(ctx, query, userId, config.TargetMonths, config.TopUpGoal, config.TopUpGoalId); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error saving emergency fund config: %v", err))
+		return fmt.Errorf("error saving emergency fund config: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+// goalColumns must stay in sync with scanGoal
+const goalColumns = `
+				id,
+				name,
+				COALESCE(description, ''),
+				years_left,
+				inflation_percentage,
+				today_amount,
+				allocated_amount,
+				sip_step_up_percentage,
+				is_due,
+				current_sip,
+				priority`
+
+func scanGoal(row interface{ Scan(dest ...any) error }) (entity.Goals, error) {
+	var goal entity.Goals
+	err := row.Scan(
+		&goal.ID,
+		&goal.Name,
+		&goal.Description,
+		&goal.YearsLeft,
+		&goal.InflationPercentage,
+		&goal.TodayAmount,
+		&goal.AllocatedAmount,
+		&goal.SIPStepUpPercentage,
+		&goal.IsDue,
+		&goal.CurrentSIP,
+		&goal.Priority,
+	)
+	return goal, err
+}
+
+func (r *ResourceRepository) GetGoals(ctx context.Context, userId int64) ([]entity.Goals, error) {
+	var goals []entity.Goals
+
+	query := `SELECT ` + goalColumns + `
+			  FROM goals
+			  WHERE user_id = $1
+			  ORDER BY id`
+
+	// Execute the query
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying goals data: %v", err)
+	}
+	defer rows.Close() // Ensure the rows iterator is closed properly
+
+	// Iterate through the rows
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning goal row: %v", err)
+		}
+		goals = append(goals, goal)
+	}
+
+	// Check for any errors that occurred during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %v", err)
+	}
+
+	// Return the list of goals
+	return goals, nil
+}
+
+func (r *ResourceRepository) CreateGoal(ctx context.Context, userId int64, goal entity.Goals) (*entity.Goals, error) {
+	query := `INSERT INTO goals (
+				user_id,
+				name,
+				description,
+				years_left,
+				inflation_percentage,
+				today_amount,
+				allocated_amount,
+				sip_step_up_percentage,
+				is_due,
+				current_sip,
+				priority
+			  )
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			  RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		userId,
+		goal.Name,
+		goal.Description,
+		goal.YearsLeft,
+		goal.InflationPercentage,
+		goal.TodayAmount,
+		goal.AllocatedAmount,
+		goal.SIPStepUpPercentage,
+		goal.IsDue,
+		goal.CurrentSIP,
+		goal.Priority,
+	).Scan(&goal.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating goal: %v", err))
+		return nil, fmt.Errorf("error creating goal: %w", err)
+	}
+
+	return &goal, nil
+}
+
+// GetGoalById returns nil when the goal does not exist for the user
+func (r *ResourceRepository) GetGoalById(ctx context.Context, userId int64, goalId int64) (*entity.Goals, error) {
+	query := `SELECT ` + goalColumns + `
+			  FROM goals
+			  WHERE id = $1 AND user_id = $2`
+
+	goal, err := scanGoal(r.db.QueryRowContext(ctx, query, goalId, userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying goal: %v", err))
+		return nil, fmt.Errorf("error querying goal: %w", err)
+	}
+
+	return &goal, nil
+}
+
+// UpdateGoal returns false when the goal does not exist for the user
+func (r *ResourceRepository) UpdateGoal(ctx context.Context, userId int64, goal entity.Goals) (bool, error) {
+	query := `UPDATE goals
+			  SET
+				name = $1,
+				description = $2,
+				years_left = $3,
+				inflation_percentage = $4,
+				today_amount = $5,
+				allocated_amount = $6,
+				sip_step_up_percentage = $7,
+				is_due = $8,
+				current_sip = $9,
+				priority = $10
+			  WHERE id = $11 AND user_id = $12`
+
+	result, err := r.db.ExecContext(ctx, query,
+		goal.Name,
+		goal.Description,
+		goal.YearsLeft,
+		goal.InflationPercentage,
+		goal.TodayAmount,
+		goal.AllocatedAmount,
+		goal.SIPStepUpPercentage,
+		goal.IsDue,
+		goal.CurrentSIP,
+		goal.Priority,
+		goal.ID,
+		userId,
+	)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error updating goal: %v", err))
+		return false, fmt.Errorf("error updating goal: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error updating goal: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteGoal returns false when the goal does not exist for the user
+func (r *ResourceRepository) DeleteGoal(ctx context.Context, userId int64, goalId int64) (bool, error) {
+	query := `DELETE FROM goals WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, goalId, userId)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting goal: %v", err))
+		return false, fmt.Errorf("error deleting goal: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting goal: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RollForwardGoals moves every goal of the user one year closer, at most once per plan year.
+// alreadyRan is true when the roll-forward for the plan year was done earlier, the existing record is returned
+func (r *ResourceRepository) RollForwardGoals(ctx context.Context, userId int64, planYear int) (rollForward *entity.GoalRollForward, alreadyRan bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("error starting goal roll forward: %w", err)
+	}
+	defer tx.Rollback()
+
+	record := entity.GoalRollForward{PlanYear: planYear}
+
+	// the unique (user_id, plan_year) key makes the roll forward idempotent
+	insertQuery := `INSERT INTO goal_roll_forward (user_id, plan_year, goals_updated)
+					VALUES ($1, $2, 0)
+					ON CONFLICT (user_id, plan_year) DO NOTHING
+					RETURNING id, ran_at`
+
+	err = tx.QueryRowContext(ctx, insertQuery, userId, planYear).Scan(&record.ID, &record.RanAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		existing, err := r.getGoalRollForward(ctx, userId, planYear)
+		if err != nil {
+			return nil, false, err
+		}
+		return existing, true, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error recording goal roll forward: %v", err))
+		return nil, false, fmt.Errorf("error recording goal roll forward: %w", err)
+	}
+
+	// SET expressions see the old years_left
+	updateQuery := `UPDATE goals
+					SET
+						years_left = years_left - 1,
+						is_due = (years_left - 1 = 0)
+					WHERE user_id = $1 AND years_left > 0`
+
+	result, err := tx.ExecContext(ctx, updateQuery, userId)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error rolling forward goals: %v", err))
+		return nil, false, fmt.Errorf("error rolling forward goals: %w", err)
+	}
+
+	record.GoalsUpdated, err = result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("error rolling forward goals: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE goal_roll_forward SET goals_updated = $1 WHERE id = $2`, record.GoalsUpdated, record.ID)
+	if err != nil {
+		return nil, false, fmt.Errorf("error recording goal roll forward: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("error committing goal roll forward: %w", err)
+	}
+
+	return &record, false, nil
+}
+
+func (r *ResourceRepository) getGoalRollForward(ctx context.Context, userId int64, planYear int) (*entity.GoalRollForward, error) {
+	query := `SELECT id, plan_year, goals_updated, ran_at
+			  FROM goal_roll_forward
+			  WHERE user_id = $1 AND plan_year = $2`
+
+	var record entity.GoalRollForward
+	err := r.db.QueryRowContext(ctx, query, userId, planYear).Scan(&record.ID, &record.PlanYear, &record.GoalsUpdated, &record.RanAt)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying goal roll forward: %v", err))
+		return nil, fmt.Errorf("error querying goal roll forward: %w", err)
+	}
+
+	return &record, nil
+}
+
+func (r *ResourceRepository) GetGoalRollForwardHistory(ctx context.Context, userId int64) ([]entity.GoalRollForward, error) {
+	query := `SELECT id, plan_year, goals_updated, ran_at
+			  FROM goal_roll_forward
+			  WHERE user_id = $1
+			  ORDER BY plan_year DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying goal roll forward history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []entity.GoalRollForward{}
+	for rows.Next() {
+		var record entity.GoalRollForward
+		if err := rows.Scan(&record.ID, &record.PlanYear, &record.GoalsUpdated, &record.RanAt); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning goal roll forward row: %w", err)
+		}
+		history = append(history, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetUserIdsWithGoals is used by the scheduler to roll forward every user's goals
+func (r *ResourceRepository) GetUserIdsWithGoals(ctx context.Context) ([]int64, error) {
+	query := `SELECT DISTINCT user_id FROM goals WHERE user_id IS NOT NULL`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying users with goals: %w", err)
+	}
+	defer rows.Close()
+
+	var userIds []int64
+	for rows.Next() {
+		var userId int64
+		if err := rows.Scan(&userId); err != nil {
+			return nil, fmt.Errorf("error scanning user id row: %w", err)
+		}
+		userIds = append(userIds, userId)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return userIds, nil
+}
@@ -6,18 +6,109 @@ import (
 	"fmt"
 	"master-finanacial-planner/internal/entity"
 	"master-finanacial-planner/internal/logger"
+	"time"
 )
 
 type ResourceRepo interface {
 	GetAssetClass(ctx context.Context) ([]entity.AssetClass, error)
 	GetAllAllocationTypeConfig(ctx context.Context) ([]AllocationTypeConfig, error)
-	GetInvestingSurplus(ctx context.Context) (float64, error)
-	GetLiquidAndIlliquidAssets(ctx context.Context) (map[string]float64, error)
-	GetAllLiability(ctx context.Context) (float64, error)
-	GetGoals(ctx context.Context) ([]entity.Goals, error)
+	GetInvestingSurplus(ctx context.Context, userId int64) (float64, error)
+	GetLiquidAndIlliquidAssets(ctx context.Context, userId int64) (map[string]float64, error)
+	GetGoals(ctx context.Context, userId int64) ([]entity.Goals, error)
 	GetAllocationByYearLeft(ctx context.Context, yearsLeft int64) ([]entity.AllocationType, error)
 	GetAllocationConfigByAllocationTypeId(ctx context.Context, allocationTypeId int64) ([]entity.AllocationTypeConfig, error)
-	GetCurrentInvestableData(ctx context.Context) ([]entity.InvestableAssetAllocation, error)
+	GetCurrentInvestableData(ctx context.Context, userId int64) ([]entity.InvestableAssetAllocation, error)
+
+	// goal
+	CreateGoal(ctx context.Context, userId int64, goal entity.Goals) (*entity.Goals, error)
+	GetGoalById(ctx context.Context, userId int64, goalId int64) (*entity.Goals, error)
+	UpdateGoal(ctx context.Context, userId int64, goal entity.Goals) (bool, error)
+	DeleteGoal(ctx context.Context, userId int64, goalId int64) (bool, error)
+	RollForwardGoals(ctx context.Context, userId int64, planYear int) (*entity.GoalRollForward, bool, error)
+	GetGoalRollForwardHistory(ctx context.Context, userId int64) ([]entity.GoalRollForward, error)
+	GetUserIdsWithGoals(ctx context.Context) ([]int64, error)
+
+	// asset sub category
+	GetAssetSubCategories(ctx context.Context, assetClassId int64) ([]entity.AssetSubCategory, error)
+	GetAssetSubCategoryById(ctx context.Context, subCategoryId int64) (*entity.AssetSubCategory, error)
+	CreateAssetSubCategory(ctx context.Context, subCategory entity.AssetSubCategory) (*entity.AssetSubCategory, error)
+	UpdateAssetSubCategory(ctx context.Context, subCategory entity.AssetSubCategory) (bool, error)
+	DeleteAssetSubCategory(ctx context.Context, subCategoryId int64) (bool, error)
+	GetSubCategoryHoldings(ctx context.Context, userId int64, excludeEmergencyFund bool) ([]entity.SubCategoryHolding, error)
+
+	// cashflow
+	GetCashflows(ctx context.Context, userId int64) ([]entity.Cashflow, error)
+	GetCashflowById(ctx context.Context, userId int64, cashflowId int64) (*entity.Cashflow, error)
+	CreateCashflow(ctx context.Context, userId int64, cashflow entity.Cashflow) (*entity.Cashflow, error)
+	UpdateCashflow(ctx context.Context, userId int64, cashflow entity.Cashflow) (bool, error)
+	DeleteCashflow(ctx context.Context, userId int64, cashflowId int64) (bool, error)
+
+	// bank statement import
+	GetCashflowRules(ctx context.Context, userId int64) ([]entity.CashflowRule, error)
+	CreateCashflowRule(ctx context.Context, userId int64, rule entity.CashflowRule) (*entity.CashflowRule, error)
+	UpdateCashflowRule(ctx context.Context, userId int64, rule entity.CashflowRule) (bool, error)
+	DeleteCashflowRule(ctx context.Context, userId int64, ruleId int64) (bool, error)
+	CreateBankTransactions(ctx context.Context, userId int64, transactions []entity.BankTransaction) (int, int, error)
+	GetBankTransactions(ctx context.Context, userId int64, since time.Time) ([]entity.BankTransaction, error)
+	ReplaceBankImportCashflows(ctx context.Context, userId int64, cashflows []entity.Cashflow) ([]entity.Cashflow, error)
+
+	// liability
+	GetLiabilities(ctx context.Context, userId int64) ([]entity.Liability, error)
+	GetLiabilityById(ctx context.Context, userId int64, liabilityId int64) (*entity.Liability, error)
+	CreateLiability(ctx context.Context, userId int64, liability entity.Liability) (*entity.Liability, error)
+	UpdateLiability(ctx context.Context, userId int64, liability entity.Liability) (bool, error)
+	DeleteLiability(ctx context.Context, userId int64, liabilityId int64) (bool, error)
+
+	// investment
+	GetInvestments(ctx context.Context, userId int64) ([]entity.Investment, error)
+	GetInvestmentById(ctx context.Context, userId int64, investmentId int64) (*entity.Investment, error)
+	CreateInvestment(ctx context.Context, userId int64, investment entity.Investment) (*entity.Investment, error)
+	UpdateInvestment(ctx context.Context, userId int64, investment entity.Investment) (bool, error)
+	DeleteInvestment(ctx context.Context, userId int64, investmentId int64) (bool, error)
+
+	// investment transaction
+	GetInvestmentTransactions(ctx context.Context, userId int64, investmentId int64) ([]entity.InvestmentTransaction, error)
+	CreateInvestmentTransaction(ctx context.Context, userId int64, transaction entity.InvestmentTransaction) (*entity.InvestmentTransaction, error)
+	DeleteInvestmentTransaction(ctx context.Context, userId int64, investmentId int64, transactionId int64) (bool, error)
+
+	// mutual fund nav
+	ImportMutualFundNAVs(ctx context.Context, records []entity.MutualFundNAVRecord) (int, int, error)
+	RevalueInvestmentsFromNAV(ctx context.Context) (int64, error)
+	GetMutualFundScheme(ctx context.Context, schemeCode string) (*entity.MutualFundScheme, error)
+	GetLatestMutualFundNAV(ctx context.Context, schemeCode string) (*entity.MutualFundNAV, error)
+	GetMutualFundNAVHistory(ctx context.Context, schemeCode string) ([]entity.MutualFundNAV, error)
+
+	// admin
+	CreateAssetClass(ctx context.Context, assetClass entity.AssetClass) (*entity.AssetClass, error)
+	UpdateAssetClass(ctx context.Context, assetClass entity.AssetClass) (bool, error)
+	DeleteAssetClass(ctx context.Context, assetClassId int64) (bool, error)
+	GetAllocationTypes(ctx context.Context) ([]entity.AllocationType, error)
+	CreateAllocationType(ctx context.Context, allocationType entity.AllocationType, config []entity.AllocationTypeConfig) (*entity.AllocationType, error)
+	UpdateAllocationTypes(ctx context.Context, allocationTypes []entity.AllocationType) (bool, error)
+	DeleteAllocationType(ctx context.Context, allocationTypeId int64) (bool, error)
+	ReplaceAllocationTypeConfig(ctx context.Context, allocationTypeId int64, config []entity.AllocationTypeConfig) error
+	GetAllocationTypeBlends(ctx context.Context) ([]entity.AllocationTypeBlend, error)
+	ReplaceAllocationTypeBlend(ctx context.Context, allocationTypeId int64, blends []entity.AllocationTypeBlend) error
+	GetAssetClassCorrelations(ctx context.Context) ([]entity.AssetClassCorrelation, error)
+	ReplaceAssetClassCorrelations(ctx context.Context, correlations []entity.AssetClassCorrelation) error
+
+	// income tax
+	GetTaxRegimeConfigs(ctx context.Context, financialYear string) ([]entity.TaxRegimeConfig, error)
+	ReplaceTaxRegimeConfigs(ctx context.Context, financialYear string, configs []entity.TaxRegimeConfig) error
+	GetTaxSectionContributions(ctx context.Context, userId int64, from time.Time, to time.Time) (map[string]float64, error)
+
+	// emergency fund
+	GetEmergencyFundConfig(ctx context.Context, userId int64) (*entity.EmergencyFundConfig, error)
+	SaveEmergencyFundConfig(ctx context.Context, userId int64, config entity.EmergencyFundConfig) error
+
+	// net worth
+	SaveNetWorthSnapshot(ctx context.Context, userId int64, snapshot *entity.NetWorthSnapshot, overwrite bool) (bool, error)
+	GetNetWorthSnapshots(ctx context.Context, userId int64) ([]entity.NetWorthSnapshot, error)
+
+	// user
+	CreateUser(ctx context.Context, user entity.User) (*entity.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetAllUserIds(ctx context.Context) ([]int64, error)
 }
 
 type ResourceRepository struct {
@@ -33,7 +124,10 @@ func NewResource(db *sql.DB) *ResourceRepository {
 func (r *ResourceRepository) GetAssetClass(ctx context.Context) ([]entity.AssetClass, error) {
 	var assetClasses []entity.AssetClass
 
-	query := "SELECT id, name, expected_return_in_percentage FROM asset_class"
+	query := `SELECT id, name, expected_return_in_percentage, volatility_in_percentage,
+					 stcg_rate_in_percentage, ltcg_rate_in_percentage, ltcg_holding_period_in_months, ltcg_exemption_limit, indexation
+			  FROM asset_class
+			  ORDER BY id`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("error querying asset class data: %v", err)
@@ -42,8 +136,17 @@ func (r *ResourceRepository) GetAssetClass(ctx context.Context) ([]entity.AssetC
 
 	for rows.Next() {
 		var assetClass entity.AssetClass
-		if err := rows.Scan(&assetClass.ID, &assetClass.Name, &assetClass.ExpectedReturnInPercentage); //&assetClass.ExpectedReturnInPercentage
-		err != nil {
+		if err := rows.Scan(
+			&assetClass.ID,
+			&assetClass.Name,
+			&assetClass.ExpectedReturnInPercentage,
+			&assetClass.VolatilityInPercentage,
+			&assetClass.STCGRateInPercentage,
+			&assetClass.LTCGRateInPercentage,
+			&assetClass.LTCGHoldingPeriodInMonths,
+			&assetClass.LTCGExemptionLimit,
+			&assetClass.Indexation,
+		); err != nil {
 			logger.LogError(ctx, err.Error())
 			return nil, fmt.Errorf("error scanning asset class row: %v", err)
 		}
@@ -117,18 +220,28 @@ func (r *ResourceRepository) GetAllAllocationTypeConfig(ctx context.Context) ([]
 	return assetClasses, nil
 }
 
-func (r *ResourceRepository) GetInvestingSurplus(ctx context.Context) (float64, error) {
+// GetInvestingSurplus returns the monthly surplus of the cashflows active today, quarterly and annual cashflows
+// are spread over their months and one-time cashflows are left out
+func (r *ResourceRepository) GetInvestingSurplus(ctx context.Context, userId int64) (float64, error) {
 	query := `SELECT 
-					SUM(CASE
+					COALESCE(SUM(CASE
 							WHEN is_inflow = TRUE THEN amount
 							WHEN is_inflow = FALSE THEN -amount
-						END) AS total_surplus
-				FROM cashflow;`
+						END / CASE frequency
+							WHEN 'quarterly' THEN 3
+							WHEN 'annual' THEN 12
+							ELSE 1
+						END), 0) AS total_surplus
+				FROM cashflow
+				WHERE user_id = $1
+				  AND frequency <> 'one-time'
+				  AND (start_date IS NULL OR start_date <= CURRENT_DATE)
+				  AND (end_date IS NULL OR end_date >= CURRENT_DATE);`
 
 	var totalSurplus float64
 
 	// Use QueryRowContext for a query expecting a single row of data
-	err := r.db.QueryRowContext(ctx, query).Scan(&totalSurplus)
+	err := r.db.QueryRowContext(ctx, query, userId).Scan(&totalSurplus)
 	if err != nil {
 		// Log the error and return a detailed error message
 		logger.LogError(ctx, fmt.Sprintf("error querying investing surplus: %v", err))
@@ -139,13 +252,14 @@ func (r *ResourceRepository) GetInvestingSurplus(ctx context.Context) (float64,
 	return totalSurplus, nil
 }
 
-func (r *ResourceRepository) GetLiquidAndIlliquidAssets(ctx context.Context) (map[string]float64, error) {
+func (r *ResourceRepository) GetLiquidAndIlliquidAssets(ctx context.Context, userId int64) (map[string]float64, error) {
 	// Define the query to get liquid and illiquid asset sums
 	query := `
 		SELECT 
 			type,
 			SUM(amount) 
 		FROM investments
+		WHERE user_id = $1
 		GROUP BY type
 	`
 
@@ -153,7 +267,7 @@ func (r *ResourceRepository) GetLiquidAndIlliquidAssets(ctx context.Context) (ma
 	assets := make(map[string]float64)
 
 	// Execute the query
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, userId)
 	if err != nil {
 		return nil, fmt.Errorf("error querying liquid and illiquid assets data: %v", err)
 	}
@@ -182,71 +296,6 @@ func (r *ResourceRepository) GetLiquidAndIlliquidAssets(ctx context.Context) (ma
 	return assets, nil
 }
 
-func (r *ResourceRepository) GetAllLiability(ctx context.Context) (float64, error) {
-	// Define the query to get the sum of liabilities
-	query := `SELECT SUM(amount) FROM liabilities`
-
-	var totalAmount float64
-
-	// Use QueryRowContext since we expect a single value
-	err := r.db.QueryRowContext(ctx, query).Scan(&totalAmount)
-	if err != nil {
-		logger.LogError(ctx, fmt.Sprintf("Error querying total liabilities: %v. Query: %s", err, query))
-		return 0, fmt.Errorf("error querying total liabilities: %w", err)
-	}
-
-	return totalAmount, nil
-}
-
-func (r *ResourceRepository) GetGoals(ctx context.Context) ([]entity.Goals, error) {
-	var goals []entity.Goals
-
-	query := `SELECT 
-				id,
-				name,
-				description,
-				years_left,
-				inflation_percentage,
-				today_amount,
-				allocated_amount,
-				sip_step_up_percentage
-			  FROM goals`
-
-	// Execute the query
-	rows, err := r.db.QueryContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("error querying goals data: %v", err)
-	}
-	defer rows.Close() // Ensure the rows iterator is closed properly
-
-	// Iterate through the rows
-	for rows.Next() {
-		var goal entity.Goals
-		if err := rows.Scan(
-			&goal.ID,
-			&goal.Name,
-			&goal.Description,
-			&goal.YearsLeft,
-			&goal.InflationPercentage,
-			&goal.TodayAmount,
-			&goal.AllocatedAmount,
-			&goal.SIPStepUpPercentage,
-		); err != nil {
-			logger.LogError(ctx, err.Error())
-			return nil, fmt.Errorf("error scanning goal row: %v", err)
-		}
-		goals = append(goals, goal)
-	}
-
-	// Check for any errors that occurred during iteration
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %v", err)
-	}
-
-	// Return the list of goals
-	return goals, nil
-}
-
 func (r *ResourceRepository) GetAllocationByYearLeft(ctx context.Context, yearsLeft int64) ([]entity.AllocationType, error) {
 	var allocationTypes []entity.AllocationType
 
@@ -329,22 +378,22 @@ func (r *ResourceRepository) GetAllocationConfigByAllocationTypeId(ctx context.C
 	return allocationTypeConfigs, nil
 }
 
-func (r *ResourceRepository) GetCurrentInvestableData(ctx context.Context) ([]entity.InvestableAssetAllocation, error) {
+func (r *ResourceRepository) GetCurrentInvestableData(ctx context.Context, userId int64) ([]entity.InvestableAssetAllocation, error) {
 	var currentInvestableAllocations []entity.InvestableAssetAllocation
 
 	query := `Select
 				   ac.id as asset_id,
 				   ac.name,
 				   COALESCE(sum(amount), 0) as value,
-				   ROUND(((SUM(coalesce(investments.amount, 0)) * 100.0) / SUM(SUM(investments.amount)) OVER ())::numeric, 2) AS contribution_percentage
+				   COALESCE(ROUND(((SUM(coalesce(investments.amount, 0)) * 100.0) / NULLIF(SUM(SUM(investments.amount)) OVER (), 0))::numeric, 2), 0) AS contribution_percentage
 			from
 				investments
 			Right outer join
 				asset_class ac
-				on investments.asset_id = ac.id and type = 'liquid'
+				on investments.asset_id = ac.id and type = 'liquid' and not investments.is_emergency_fund and investments.user_id = $1
 			group by ac.id, ac.name`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, userId)
 	if err != nil {
 		// Log the error and return with more context
 		logger.LogError(ctx, fmt.Sprintf("error querying investable data: %v", err))
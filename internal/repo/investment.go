@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+// investmentColumns must stay in sync with scanInvestment
+const investmentColumns = `
+				i.id,
+				i.asset_id,
+				ac.name,
+				i.name,
+				i.amount,
+				i.type,
+				i.asset_sub_category_id,
+				i.scheme_code,
+				i.units,
+				i.tax_section,
+				i.is_emergency_fund`
+
+func scanInvestment(row interface{ Scan(dest ...any) error }) (entity.Investment, error) {
+	var investment entity.Investment
+	err := row.Scan(
+		&investment.ID,
+		&investment.AssetId,
+		&investment.AssetName,
+		&investment.Name,
+		&investment.Amount,
+		&investment.Type,
+		&investment.AssetSubCategoryId,
+		&investment.SchemeCode,
+		&investment.Units,
+		&investment.TaxSection,
+		&investment.IsEmergencyFund,
+	)
+	return investment, err
+}
+
+func (r *ResourceRepository) GetInvestments(ctx context.Context, userId int64) ([]entity.Investment, error) {
+	query := `SELECT ` + investmentColumns + `
+			  FROM investments i
+			  JOIN asset_class ac
+				ON i.asset_id = ac.id
+			  WHERE i.user_id = $1
+			  ORDER BY i.id`
+
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying investments data: %w", err)
+	}
+	defer rows.Close()
+
+	investments := []entity.Investment{}
+	for rows.Next() {
+		investment, err := scanInvestment(rows)
+		if err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning investment row: %w", err)
+		}
+		investments = append(investments, investment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return investments, nil
+}
+
+// GetInvestmentById returns nil when the investment does not exist for the user
+func (r *ResourceRepository) GetInvestmentById(ctx context.Context, userId int64, investmentId int64) (*entity.Investment, error) {
+	query := `SELECT ` + investmentColumns + `
+			  FROM investments i
+			  JOIN asset_class ac
+				ON i.asset_id = ac.id
+			  WHERE i.id = $1 AND i.user_id = $2`
+
+	investment, err := scanInvestment(r.db.QueryRowContext(ctx, query, investmentId, userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying investment: %v", err))
+		return nil, fmt.Errorf("error querying investment: %w", err)
+	}
+
+	return &investment, nil
+}
+
+func (r *ResourceRepository) CreateInvestment(ctx context.Context, userId int64, investment entity.Investment) (*entity.Investment, error) {
+	query := `INSERT INTO investments (user_id, asset_id, name, amount, type, asset_sub_category_id, scheme_code, units, tax_section, is_emergency_fund)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			  RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		userId,
+		investment.AssetId,
+		investment.Name,
+		investment.Amount,
+		investment.Type,
+		investment.AssetSubCategoryId,
+		investment.SchemeCode,
+		investment.Units,
+		investment.TaxSection,
+		investment.IsEmergencyFund,
+	).Scan(&investment.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating investment: %v", err))
+		return nil, fmt.Errorf("error creating investment: %w", err)
+	}
+
+	return &investment, nil
+}
+
+// UpdateInvestment returns false when the investment does not exist for the user
+func (r *ResourceRepository) UpdateInvestment(ctx context.Context, userId int64, investment entity.Investment) (bool, error) {
+	query := `UPDATE investments
+			  SET
+				asset_id = $1,
+				name = $2,
+				amount = $3,
+				type = $4,
+				asset_sub_category_id = $5,
+				scheme_code = $6,
+				units = $7,
+				tax_section = $8,
+				is_emergency_fund = $9
+			  WHERE id = $10 AND user_id = $11`
+
+	result, err := r.db.ExecContext(ctx, query,
+		investment.AssetId,
+		investment.Name,
+		investment.Amount,
+		investment.Type,
+		investment.AssetSubCategoryId,
+		investment.SchemeCode,
+		investment.Units,
+		investment.TaxSection,
+		investment.IsEmergencyFund,
+		investment.ID,
+		userId,
+	)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error updating investment: %v", err))
+		return false, fmt.Errorf("error updating investment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error updating investment: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteInvestment returns false when the investment does not exist for the user
+func (r *ResourceRepository) DeleteInvestment(ctx context.Context, userId int64, investmentId int64) (bool, error) {
+	query := `DELETE FROM investments WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, investmentId, userId)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting investment: %v", err))
+		return false, fmt.Errorf("error deleting investment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting investment: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
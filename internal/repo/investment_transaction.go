@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+// GetInvestmentTransactions returns the ledger ordered by date, investmentId 0 returns the ledger of every holding of the user
+func (r *ResourceRepository) GetInvestmentTransactions(ctx context.Context, userId int64, investmentId int64) ([]entity.InvestmentTransaction, error) {
+	query := `SELECT
+				id,
+				investment_id,
+				type,
+				transaction_date,
+				amount,
+				units
+			  FROM investment_transaction
+			  WHERE user_id = $1 AND ($2 = 0 OR investment_id = $2)
+			  ORDER BY transaction_date, id`
+
+	rows, err := r.db.QueryContext(ctx, query, userId, investmentId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying investment transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions := []entity.InvestmentTransaction{}
+	for rows.Next() {
+		var transaction entity.InvestmentTransaction
+		if err := rows.Scan(
+			&transaction.ID,
+			&transaction.InvestmentId,
+			&transaction.Type,
+			&transaction.TransactionDate,
+			&transaction.Amount,
+			&transaction.Units,
+		); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning investment transaction row: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *ResourceRepository) CreateInvestmentTransaction(ctx context.Context, userId int64, transaction entity.InvestmentTransaction) (*entity.InvestmentTransaction, error) {
+	query := `INSERT INTO investment_transaction (user_id, investment_id, type, transaction_date, amount, units)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		userId,
+		transaction.InvestmentId,
+		transaction.Type,
+		transaction.TransactionDate,
+		transaction.Amount,
+		transaction.Units,
+	).Scan(&transaction.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating investment transaction: %v", err))
+		return nil, fmt.Errorf("error creating investment transaction: %w", err)
+	}
+
+	return &transaction, nil
+}
+
+// DeleteInvestmentTransaction returns false when the transaction does not exist for the holding of the user
+func (r *ResourceRepository) DeleteInvestmentTransaction(ctx context.Context, userId int64, investmentId int64, transactionId int64) (bool, error) {
+	query := `DELETE FROM investment_transaction WHERE id = $1 AND investment_id = $2 AND user_id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, transactionId, investmentId, userId)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting investment transaction: %v", err))
+		return false, fmt.Errorf("error deleting investment transaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting investment transaction: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,176 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+// liabilityColumns must stay in sync with scanLiability
+const liabilityColumns = `
+				id,
+				name,
+				amount,
+				due_date,
+				COALESCE(is_long_term, FALSE),
+				interest_rate_in_percentage,
+				tenure_in_months,
+				emi,
+				start_date`
+
+func scanLiability(row interface{ Scan(dest ...any) error }) (entity.Liability, error) {
+	var liability entity.Liability
+	err := row.Scan(
+		&liability.ID,
+		&liability.Name,
+		&liability.Amount,
+		&liability.DueDate,
+		&liability.IsLongTerm,
+		&liability.InterestRateInPercentage,
+		&liability.TenureInMonths,
+		&liability.EMI,
+		&liability.StartDate,
+	)
+	return liability, err
+}
+
+func (r *ResourceRepository) GetLiabilities(ctx context.Context, userId int64) ([]entity.Liability, error) {
+	query := `SELECT ` + liabilityColumns + `
+			  FROM liabilities
+			  WHERE user_id = $1
+			  ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying liabilities data: %w", err)
+	}
+	defer rows.Close()
+
+	liabilities := []entity.Liability{}
+	for rows.Next() {
+		liability, err := scanLiability(rows)
+		if err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning liability row: %w", err)
+		}
+		liabilities = append(liabilities, liability)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return liabilities, nil
+}
+
+// GetLiabilityById returns nil when the liability does not exist for the user
+func (r *ResourceRepository) GetLiabilityById(ctx context.Context, userId int64, liabilityId int64) (*entity.Liability, error) {
+	query := `SELECT ` + liabilityColumns + `
+			  FROM liabilities
+			  WHERE id = $1 AND user_id = $2`
+
+	liability, err := scanLiability(r.db.QueryRowContext(ctx, query, liabilityId, userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying liability: %v", err))
+		return nil, fmt.Errorf("error querying liability: %w", err)
+	}
+
+	return &liability, nil
+}
+
+func (r *ResourceRepository) CreateLiability(ctx context.Context, userId int64, liability entity.Liability) (*entity.Liability, error) {
+	query := `INSERT INTO liabilities (
+				user_id,
+				name,
+				amount,
+				due_date,
+				is_long_term,
+				interest_rate_in_percentage,
+				tenure_in_months,
+				emi,
+				start_date
+			  )
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			  RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		userId,
+		liability.Name,
+		liability.Amount,
+		liability.DueDate,
+		liability.IsLongTerm,
+		liability.InterestRateInPercentage,
+		liability.TenureInMonths,
+		liability.EMI,
+		liability.StartDate,
+	).Scan(&liability.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating liability: %v", err))
+		return nil, fmt.Errorf("error creating liability: %w", err)
+	}
+
+	return &liability, nil
+}
+
+// UpdateLiability returns false when the liability does not exist for the user
+func (r *ResourceRepository) UpdateLiability(ctx context.Context, userId int64, liability entity.Liability) (bool, error) {
+	query := `UPDATE liabilities
+			  SET
+				name = $1,
+				amount = $2,
+				due_date = $3,
+				is_long_term = $4,
+				interest_rate_in_percentage = $5,
+				tenure_in_months = $6,
+				emi = $7,
+				start_date = $8
+			  WHERE id = $9 AND user_id = $10`
+
+	result, err := r.db.ExecContext(ctx, query,
+		liability.Name,
+		liability.Amount,
+		liability.DueDate,
+		liability.IsLongTerm,
+		liability.InterestRateInPercentage,
+		liability.TenureInMonths,
+		liability.EMI,
+		liability.StartDate,
+		liability.ID,
+		userId,
+	)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error updating liability: %v", err))
+		return false, fmt.Errorf("error updating liability: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error updating liability: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteLiability returns false when the liability does not exist for the user
+func (r *ResourceRepository) DeleteLiability(ctx context.Context, userId int64, liabilityId int64) (bool, error) {
+	query := `DELETE FROM liabilities WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, liabilityId, userId)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting liability: %v", err))
+		return false, fmt.Errorf("error deleting liability: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting liability: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,171 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+// ImportMutualFundNAVs upserts the schemes and their nav of the day in one transaction, a re-import of a day overwrites its nav
+func (r *ResourceRepository) ImportMutualFundNAVs(ctx context.Context, records []entity.MutualFundNAVRecord) (schemes int, navs int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error starting nav import: %w", err)
+	}
+	defer tx.Rollback()
+
+	schemeStmt, err := tx.PrepareContext(ctx, `INSERT INTO mutual_fund_scheme (scheme_code, scheme_name, isin_growth, isin_reinvestment, fund_house, category)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  ON CONFLICT (scheme_code) DO UPDATE SET
+				scheme_name = EXCLUDED.scheme_name,
+				isin_growth = EXCLUDED.isin_growth,
+				isin_reinvestment = EXCLUDED.isin_reinvestment,
+				fund_house = EXCLUDED.fund_house,
+				category = EXCLUDED.category`)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error preparing scheme import: %w", err)
+	}
+	defer schemeStmt.Close()
+
+	navStmt, err := tx.PrepareContext(ctx, `INSERT INTO mutual_fund_nav (scheme_code, nav_date, nav)
+			  VALUES ($1, $2, $3)
+			  ON CONFLICT (scheme_code, nav_date) DO UPDATE SET nav = EXCLUDED.nav`)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error preparing nav import: %w", err)
+	}
+	defer navStmt.Close()
+
+	seenSchemes := make(map[string]bool)
+	for _, record := range records {
+		if !seenSchemes[record.SchemeCode] {
+			seenSchemes[record.SchemeCode] = true
+			if _, err := schemeStmt.ExecContext(ctx,
+				record.SchemeCode,
+				record.SchemeName,
+				record.ISINGrowth,
+				record.ISINReinvestment,
+				record.FundHouse,
+				record.Category,
+			); err != nil {
+				logger.LogError(ctx, fmt.Sprintf("error importing scheme %s: %v", record.SchemeCode, err))
+				return 0, 0, fmt.Errorf("error importing scheme %s: %w", record.SchemeCode, err)
+			}
+		}
+
+		if _, err := navStmt.ExecContext(ctx, record.SchemeCode, record.NAVDate, record.NAV); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error importing nav of scheme %s: %v", record.SchemeCode, err))
+			return 0, 0, fmt.Errorf("error importing nav of scheme %s: %w", record.SchemeCode, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("error committing nav import: %w", err)
+	}
+
+	return len(seenSchemes), len(records), nil
+}
+
+// RevalueInvestmentsFromNAV sets the amount of every unit based holding to units x latest nav of its scheme
+func (r *ResourceRepository) RevalueInvestmentsFromNAV(ctx context.Context) (int64, error) {
+	query := `UPDATE investments i
+			  SET amount = i.units * latest.nav
+			  FROM (
+				SELECT DISTINCT ON (scheme_code) scheme_code, nav
+				FROM mutual_fund_nav
+				ORDER BY scheme_code, nav_date DESC
+			  ) latest
+			  WHERE i.scheme_code = latest.scheme_code AND i.units IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error revaluing investments: %v", err))
+		return 0, fmt.Errorf("error revaluing investments: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error revaluing investments: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// GetMutualFundScheme returns nil when the scheme was never imported
+func (r *ResourceRepository) GetMutualFundScheme(ctx context.Context, schemeCode string) (*entity.MutualFundScheme, error) {
+	query := `SELECT scheme_code, scheme_name, isin_growth, isin_reinvestment, fund_house, category
+			  FROM mutual_fund_scheme
+			  WHERE scheme_code = $1`
+
+	var scheme entity.MutualFundScheme
+	err := r.db.QueryRowContext(ctx, query, schemeCode).Scan(
+		&scheme.SchemeCode,
+		&scheme.SchemeName,
+		&scheme.ISINGrowth,
+		&scheme.ISINReinvestment,
+		&scheme.FundHouse,
+		&scheme.Category,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying mutual fund scheme: %v", err))
+		return nil, fmt.Errorf("error querying mutual fund scheme: %w", err)
+	}
+
+	return &scheme, nil
+}
+
+// GetLatestMutualFundNAV returns nil when the scheme has no nav yet
+func (r *ResourceRepository) GetLatestMutualFundNAV(ctx context.Context, schemeCode string) (*entity.MutualFundNAV, error) {
+	query := `SELECT scheme_code, nav_date, nav
+			  FROM mutual_fund_nav
+			  WHERE scheme_code = $1
+			  ORDER BY nav_date DESC
+			  LIMIT 1`
+
+	var nav entity.MutualFundNAV
+	err := r.db.QueryRowContext(ctx, query, schemeCode).Scan(&nav.SchemeCode, &nav.NAVDate, &nav.NAV)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying latest nav: %v", err))
+		return nil, fmt.Errorf("error querying latest nav: %w", err)
+	}
+
+	return &nav, nil
+}
+
+// GetMutualFundNAVHistory returns the navs of the scheme, latest first
+func (r *ResourceRepository) GetMutualFundNAVHistory(ctx context.Context, schemeCode string) ([]entity.MutualFundNAV, error) {
+	query := `SELECT scheme_code, nav_date, nav
+			  FROM mutual_fund_nav
+			  WHERE scheme_code = $1
+			  ORDER BY nav_date DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, schemeCode)
+	if err != nil {
+		return nil, fmt.Errorf("error querying nav history: %w", err)
+	}
+	defer rows.Close()
+
+	navs := []entity.MutualFundNAV{}
+	for rows.Next() {
+		var nav entity.MutualFundNAV
+		if err := rows.Scan(&nav.SchemeCode, &nav.NAVDate, &nav.NAV); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning nav row: %w", err)
+		}
+		navs = append(navs, nav)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return navs, nil
+}
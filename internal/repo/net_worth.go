@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+// SaveNetWorthSnapshot stores the snapshot of its month together with its asset class breakdown.
+// An existing snapshot for the month is replaced only when overwrite is set, saved is false when it was kept
+func (r *ResourceRepository) SaveNetWorthSnapshot(ctx context.Context, userId int64, snapshot *entity.NetWorthSnapshot, overwrite bool) (saved bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting net worth snapshot: %w", err)
+	}
+	defer tx.Rollback()
+
+	onConflict := `DO NOTHING`
+	if overwrite {
+		onConflict = `DO UPDATE SET
+						total_asset = EXCLUDED.total_asset,
+						liquid_asset = EXCLUDED.liquid_asset,
+						illiquid_asset = EXCLUDED.illiquid_asset,
+						liabilities = EXCLUDED.liabilities,
+						net_worth = EXCLUDED.net_worth,
+						created_at = now()`
+	}
+
+	query := `INSERT INTO net_worth_snapshot (user_id, snapshot_month, total_asset, liquid_asset, illiquid_asset, liabilities, net_worth)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  ON CONFLICT (user_id, snapshot_month) ` + onConflict + `
+			  RETURNING id, created_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		userId,
+		snapshot.SnapshotMonth,
+		snapshot.TotalAsset,
+		snapshot.LiquidAsset,
+		snapshot.IlliquidAsset,
+		snapshot.Liabilities,
+		snapshot.NetWorth,
+	).Scan(&snapshot.ID, &snapshot.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error saving net worth snapshot: %v", err))
+		return false, fmt.Errorf("error saving net worth snapshot: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM net_worth_snapshot_asset WHERE snapshot_id = $1`, snapshot.ID); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting net worth snapshot assets: %v", err))
+		return false, fmt.Errorf("error deleting net worth snapshot assets: %w", err)
+	}
+
+	assetQuery := `INSERT INTO net_worth_snapshot_asset (snapshot_id, asset_class_id, asset_class_name, liquid_value, illiquid_value)
+				   VALUES ($1, $2, $3, $4, $5)`
+
+	for _, assetClass := range snapshot.AssetClasses {
+		if _, err := tx.ExecContext(ctx, assetQuery, snapshot.ID, assetClass.AssetId, assetClass.AssetName, assetClass.LiquidValue, assetClass.IlliquidValue); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error inserting net worth snapshot asset: %v", err))
+			return false, fmt.Errorf("error inserting net worth snapshot asset: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing net worth snapshot: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetNetWorthSnapshots returns every snapshot of the user, oldest first, with its asset class breakdown
+func (r *ResourceRepository) GetNetWorthSnapshots(ctx context.Context, userId int64) ([]entity.NetWorthSnapshot, error) {
+	query := `SELECT
+				id,
+				snapshot_month,
+				total_asset,
+				liquid_asset,
+				illiquid_asset,
+				liabilities,
+				net_worth,
+				created_at
+			  FROM net_worth_snapshot
+			  WHERE user_id = $1
+			  ORDER BY snapshot_month`
+
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying net worth snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []entity.NetWorthSnapshot{}
+	indexById := make(map[int64]int)
+	for rows.Next() {
+		snapshot := entity.NetWorthSnapshot{AssetClasses: []entity.NetWorthAssetClass{}}
+		if err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.SnapshotMonth,
+			&snapshot.TotalAsset,
+			&snapshot.LiquidAsset,
+			&snapshot.IlliquidAsset,
+			&snapshot.Liabilities,
+			&snapshot.NetWorth,
+			&snapshot.CreatedAt,
+		); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning net worth snapshot row: %w", err)
+		}
+		indexById[snapshot.ID] = len(snapshots)
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	assetQuery := `SELECT
+					nwsa.snapshot_id,
+					nwsa.asset_class_id,
+					nwsa.asset_class_name,
+					nwsa.liquid_value,
+					nwsa.illiquid_value
+				   FROM net_worth_snapshot_asset nwsa
+				   JOIN net_worth_snapshot nws
+					ON nwsa.snapshot_id = nws.id
+				   WHERE nws.user_id = $1
+				   ORDER BY nwsa.snapshot_id, nwsa.asset_class_id`
+
+	assetRows, err := r.db.QueryContext(ctx, assetQuery, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying net worth snapshot assets: %w", err)
+	}
+	defer assetRows.Close()
+
+	for assetRows.Next() {
+		var snapshotId int64
+		var assetClass entity.NetWorthAssetClass
+		if err := assetRows.Scan(&snapshotId, &assetClass.AssetId, &assetClass.AssetName, &assetClass.LiquidValue, &assetClass.IlliquidValue); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning net worth snapshot asset row: %w", err)
+		}
+		assetClass.Value = assetClass.LiquidValue + assetClass.IlliquidValue
+
+		index, ok := indexById[snapshotId]
+		if !ok {
+			continue
+		}
+		snapshots[index].AssetClasses = append(snapshots[index].AssetClasses, assetClass)
+	}
+
+	if err := assetRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,228 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+	"net/http"
+)
+
+// GetAssetSubCategories returns the sub categories ordered by asset class and priority, assetClassId 0 returns all
+func (r *ResourceRepository) GetAssetSubCategories(ctx context.Context, assetClassId int64) ([]entity.AssetSubCategory, error) {
+	query := `SELECT
+				id,
+				asset_class_id,
+				name,
+				priority_order,
+				weight_in_percentage
+			  FROM asset_sub_category
+			  WHERE $1 = 0 OR asset_class_id = $1
+			  ORDER BY asset_class_id, priority_order, id`
+
+	rows, err := r.db.QueryContext(ctx, query, assetClassId)
+	if err != nil {
+		return nil, fmt.Errorf("error querying asset sub category data: %w", err)
+	}
+	defer rows.Close()
+
+	subCategories := []entity.AssetSubCategory{}
+	for rows.Next() {
+		var subCategory entity.AssetSubCategory
+		if err := rows.Scan(
+			&subCategory.ID,
+			&subCategory.AssetClassId,
+			&subCategory.Name,
+			&subCategory.PriorityOrder,
+			&subCategory.WeightInPercentage,
+		); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning asset sub category row: %w", err)
+		}
+		subCategories = append(subCategories, subCategory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return subCategories, nil
+}
+
+// GetAssetSubCategoryById returns nil when the sub category does not exist
+func (r *ResourceRepository) GetAssetSubCategoryById(ctx context.Context, subCategoryId int64) (*entity.AssetSubCategory, error) {
+	query := `SELECT
+				id,
+				asset_class_id,
+				name,
+				priority_order,
+				weight_in_percentage
+			  FROM asset_sub_category
+			  WHERE id = $1`
+
+	var subCategory entity.AssetSubCategory
+	err := r.db.QueryRowContext(ctx, query, subCategoryId).Scan(
+		&subCategory.ID,
+		&subCategory.AssetClassId,
+		&subCategory.Name,
+		&subCategory.PriorityOrder,
+		&subCategory.WeightInPercentage,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying asset sub category: %v", err))
+		return nil, fmt.Errorf("error querying asset sub category: %w", err)
+	}
+
+	return &subCategory, nil
+}
+
+func (r *ResourceRepository) CreateAssetSubCategory(ctx context.Context, subCategory entity.AssetSubCategory) (*entity.AssetSubCategory, error) {
+	query := `INSERT INTO asset_sub_category (asset_class_id, name, priority_order, weight_in_percentage)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query,
+		subCategory.AssetClassId,
+		subCategory.Name,
+		subCategory.PriorityOrder,
+		subCategory.WeightInPercentage,
+	).Scan(&subCategory.ID)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating asset sub category: %v", err))
+		return nil, fmt.Errorf("error creating asset sub category: %w", err)
+	}
+
+	return &subCategory, nil
+}
+
+// UpdateAssetSubCategory returns false when the sub category does not exist.
+// Moving it to another asset class is refused while investments use it, they would no longer match their asset class
+func (r *ResourceRepository) UpdateAssetSubCategory(ctx context.Context, subCategory entity.AssetSubCategory) (bool, error) {
+	query := `UPDATE asset_sub_category
+			  SET
+				asset_class_id = $1,
+				name = $2,
+				priority_order = $3,
+				weight_in_percentage = $4
+			  WHERE id = $5
+				AND (asset_class_id = $1 OR NOT EXISTS (SELECT 1 FROM investments WHERE asset_sub_category_id = $5))`
+
+	result, err := r.db.ExecContext(ctx, query,
+		subCategory.AssetClassId,
+		subCategory.Name,
+		subCategory.PriorityOrder,
+		subCategory.WeightInPercentage,
+		subCategory.ID,
+	)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error updating asset sub category: %v", err))
+		return false, fmt.Errorf("error updating asset sub category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error updating asset sub category: %w", err)
+	}
+	if rowsAffected > 0 {
+		return true, nil
+	}
+
+	// nothing was updated, either the sub category does not exist or its holdings pin it to its asset class
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM asset_sub_category WHERE id = $1)`, subCategory.ID).Scan(&exists); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error checking asset sub category: %v", err))
+		return false, fmt.Errorf("error checking asset sub category: %w", err)
+	}
+	if exists {
+		return false, &entity.CustomError{StatusCode: http.StatusConflict, Message: "asset sub category is still used by investments, its asset class cannot change"}
+	}
+
+	return false, nil
+}
+
+// DeleteAssetSubCategory returns false when the sub category does not exist
+func (r *ResourceRepository) DeleteAssetSubCategory(ctx context.Context, subCategoryId int64) (bool, error) {
+	query := `DELETE FROM asset_sub_category WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, subCategoryId)
+	if isForeignKeyViolation(err) {
+		return false, &entity.CustomError{StatusCode: http.StatusConflict, Message: "asset sub category is still used by investments"}
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting asset sub category: %v", err))
+		return false, fmt.Errorf("error deleting asset sub category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error deleting asset sub category: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetSubCategoryHoldings breaks the user's holdings down by asset class and sub category.
+// excludeEmergencyFund leaves out the holdings set aside as emergency fund, which are not part of the investable portfolio
+func (r *ResourceRepository) GetSubCategoryHoldings(ctx context.Context, userId int64, excludeEmergencyFund bool) ([]entity.SubCategoryHolding, error) {
+	query := `SELECT
+				ac.id AS asset_id,
+				ac.name AS asset_name,
+				COALESCE(asc2.id, 0) AS sub_category_id,
+				COALESCE(asc2.name, $2) AS sub_category_name,
+				COALESCE(SUM(CASE WHEN i.type = 'liquid' THEN i.amount END), 0) AS liquid_value,
+				COALESCE(SUM(CASE WHEN i.type <> 'liquid' THEN i.amount END), 0) AS illiquid_value,
+				SUM(i.amount) AS value,
+				COALESCE(ROUND(((SUM(i.amount) * 100.0) / NULLIF(SUM(SUM(i.amount)) OVER (), 0))::numeric, 2), 0) AS contribution_percentage
+			  FROM investments i
+			  JOIN asset_class ac
+				ON i.asset_id = ac.id
+			  LEFT JOIN asset_sub_category asc2
+				ON i.asset_sub_category_id = asc2.id
+			  WHERE i.user_id = $1 AND NOT ($3 AND i.is_emergency_fund)
+			  GROUP BY ac.id, ac.name, asc2.id, asc2.name, asc2.priority_order
+			  ORDER BY ac.id, asc2.priority_order NULLS LAST`
+
+	rows, err := r.db.QueryContext(ctx, query, userId, constant.UncategorisedSubCategoryName, excludeEmergencyFund)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying sub category holdings: %v", err))
+		return nil, fmt.Errorf("error querying sub category holdings: %w", err)
+	}
+	defer rows.Close()
+
+	holdings := []entity.SubCategoryHolding{}
+	for rows.Next() {
+		var holding entity.SubCategoryHolding
+		if err := rows.Scan(
+			&holding.AssetId,
+			&holding.AssetName,
+			&holding.SubCategoryId,
+			&holding.SubCategoryName,
+			&holding.LiquidValue,
+			&holding.IlliquidValue,
+			&holding.Value,
+			&holding.ContributionPercentage,
+		); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error scanning sub category holding row: %v", err))
+			return nil, fmt.Errorf("error scanning sub category holding row: %w", err)
+		}
+		holdings = append(holdings, holding)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return holdings, nil
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+	"time"
+)
+
+// GetTaxRegimeConfigs returns the regimes of the financial year with their slabs, every financial year when it is empty
+func (r *ResourceRepository) GetTaxRegimeConfigs(ctx context.Context, financialYear string) ([]entity.TaxRegimeConfig, error) {
+	query := `SELECT c.id, c.financial_year, c.regime, c.standard_deduction, c.rebate_income_limit, c.marginal_relief, c.cess_in_percentage,
+					 s.lower_limit, s.upper_limit, s.rate_in_percentage
+			  FROM tax_regime_config c
+			  LEFT JOIN tax_slab s
+				ON s.tax_regime_config_id = c.id
+			  WHERE $1 = '' OR c.financial_year = $1
+			  ORDER BY c.financial_year, c.regime, s.lower_limit`
+
+	rows, err := r.db.QueryContext(ctx, query, financialYear)
+	if err != nil {
+		return nil, fmt.Errorf("error querying tax regime configs: %w", err)
+	}
+	defer rows.Close()
+
+	configs := []entity.TaxRegimeConfig{}
+	indexById := make(map[int64]int)
+	for rows.Next() {
+		var id int64
+		var config entity.TaxRegimeConfig
+		var lowerLimit, upperLimit, rate *float64
+		if err := rows.Scan(
+			&id,
+			&config.FinancialYear,
+			&config.Regime,
+			&config.StandardDeduction,
+			&config.RebateIncomeLimit,
+			&config.MarginalRelief,
+			&config.CessInPercentage,
+			&lowerLimit,
+			&upperLimit,
+			&rate,
+		); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning tax regime config row: %w", err)
+		}
+
+		index, ok := indexById[id]
+		if !ok {
+			config.Slabs = []entity.TaxSlab{}
+			configs = append(configs, config)
+			index = len(configs) - 1
+			indexById[id] = index
+		}
+
+		// a regime without slabs comes back as a single row of nulls
+		if lowerLimit != nil {
+			configs[index].Slabs = append(configs[index].Slabs, entity.TaxSlab{
+				LowerLimit:       *lowerLimit,
+				UpperLimit:       upperLimit,
+				RateInPercentage: *rate,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return configs, nil
+}
+
+// ReplaceTaxRegimeConfigs swaps every regime of the financial year with their slabs in one transaction
+func (r *ResourceRepository) ReplaceTaxRegimeConfigs(ctx context.Context, financialYear string, configs []entity.TaxRegimeConfig) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting tax regime config update: %w", err)
+	}
+	defer tx.Rollback()
+
+	// slabs go with their regime on delete cascade
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tax_regime_config WHERE financial_year = $1`, financialYear); err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error deleting tax regime configs: %v", err))
+		return fmt.Errorf("error deleting tax regime configs: %w", err)
+	}
+
+	configQuery := `INSERT INTO tax_regime_config (financial_year, regime, standard_deduction, rebate_income_limit, marginal_relief, cess_in_percentage)
+					VALUES ($1, $2, $3, $4, $5, $6)
+					RETURNING id`
+	slabQuery := `INSERT INTO tax_slab (tax_regime_config_id, lower_limit, upper_limit, rate_in_percentage)
+				  VALUES ($1, $2, $3, $4)`
+
+	for _, config := range configs {
+		var id int64
+		if err := tx.QueryRowContext(ctx, configQuery,
+			financialYear,
+			config.Regime,
+			config.StandardDeduction,
+			config.RebateIncomeLimit,
+			config.MarginalRelief,
+			config.CessInPercentage,
+		).Scan(&id); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error inserting tax regime config: %v", err))
+			return fmt.Errorf("error inserting tax regime config: %w", err)
+		}
+
+		for _, slab := range config.Slabs {
+			if _, err := tx.ExecContext(ctx, slabQuery, id, slab.LowerLimit, slab.UpperLimit, slab.RateInPercentage); err != nil {
+				logger.LogError(ctx, fmt.Sprintf("error inserting tax slab: %v", err))
+				return fmt.Errorf("error inserting tax slab: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing tax regime config update: %w", err)
+	}
+
+	return nil
+}
+
+// GetTaxSectionContributions sums the buys and sips into tax tagged investments between the dates, by tax section
+func (r *ResourceRepository) GetTaxSectionContributions(ctx context.Context, userId int64, from time.Time, to time.Time) (map[string]float64, error) {
+	query := `SELECT i.tax_section, SUM(t.amount)
+			  FROM investment_transaction t
+			  JOIN investments i
+				ON t.investment_id = i.id
+			  WHERE t.user_id = $1
+				AND i.tax_section IS NOT NULL
+				AND t.type IN ('buy', 'sip')
+				AND t.transaction_date BETWEEN $2 AND $3
+			  GROUP BY i.tax_section`
+
+	rows, err := r.db.QueryContext(ctx, query, userId, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error querying tax section contributions: %w", err)
+	}
+	defer rows.Close()
+
+	contributions := make(map[string]float64)
+	for rows.Next() {
+		var section string
+		var amount float64
+		if err := rows.Scan(&section, &amount); err != nil {
+			logger.LogError(ctx, err.Error())
+			return nil, fmt.Errorf("error scanning tax section contribution row: %w", err)
+		}
+		contributions[section] = amount
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return contributions, nil
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/logger"
+)
+
+func (r *ResourceRepository) CreateUser(ctx context.Context, user entity.User) (*entity.User, error) {
+	query := `INSERT INTO users (name, email, password_hash, password_salt)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id, is_admin, created_at`
+
+	err := r.db.QueryRowContext(ctx, query, user.Name, user.Email, user.PasswordHash, user.PasswordSalt).Scan(&user.ID, &user.IsAdmin, &user.CreatedAt)
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error creating user: %v", err))
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetUserByEmail returns nil when no user is registered with the email
+func (r *ResourceRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	query := `SELECT
+				id,
+				name,
+				email,
+				password_hash,
+				password_salt,
+				is_admin,
+				created_at
+			  FROM users
+			  WHERE email = $1`
+
+	var user entity.User
+	err := r.db.QueryRowContext(ctx, query, email).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.PasswordHash,
+		&user.PasswordSalt,
+		&user.IsAdmin,
+		&user.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.LogError(ctx, fmt.Sprintf("error querying user by email: %v", err))
+		return nil, fmt.Errorf("error querying user by email: %w", err)
+	}
+
+	return &user, nil
+}
+
+// GetAllUserIds is used by the schedulers which work on every user
+func (r *ResourceRepository) GetAllUserIds(ctx context.Context) ([]int64, error) {
+	query := `SELECT id FROM users ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying user ids: %w", err)
+	}
+	defer rows.Close()
+
+	var userIds []int64
+	for rows.Next() {
+		var userId int64
+		if err := rows.Scan(&userId); err != nil {
+			return nil, fmt.Errorf("error scanning user id row: %w", err)
+		}
+		userIds = append(userIds, userId)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return userIds, nil
+}
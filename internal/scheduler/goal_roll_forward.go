@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/logger"
+	"time"
+)
+
+type GoalRollForwarder interface {
+	RollForwardAllGoals(ctx context.Context, planYear int) error
+}
+
+// StartGoalRollForwardScheduler checks on every tick whether the plan year needs to be rolled forward.
+// It only acts during the roll forward month, the roll forward itself is idempotent per plan year
+func StartGoalRollForwardScheduler(ctx context.Context, rollForwarder GoalRollForwarder, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		if now.Month() == constant.GoalRollForwardMonth {
+			if err := rollForwarder.RollForwardAllGoals(ctx, now.Year()); err != nil {
+				logger.LogError(ctx, fmt.Sprintf("goal roll forward scheduler: %v", err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/logger"
+	"time"
+)
+
+type NetWorthSnapshotter interface {
+	SnapshotAllNetWorth(ctx context.Context, now time.Time) error
+}
+
+// StartNetWorthSnapshotScheduler takes the monthly net worth snapshot of every user.
+// It runs on every tick, a month which already has a snapshot is left as it is
+func StartNetWorthSnapshotScheduler(ctx context.Context, snapshotter NetWorthSnapshotter, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := snapshotter.SnapshotAllNetWorth(ctx, time.Now()); err != nil {
+			logger.LogError(ctx, fmt.Sprintf("net worth snapshot scheduler: %v", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,483 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+func (f FinanceUsecase) CreateAssetClass(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var assetClass entity.AssetClass
+	if err := helper.DecodeRequestBody(r, &assetClass); err != nil {
+		return nil, err
+	}
+
+	if err := validateAssetClass(&assetClass); err != nil {
+		return nil, err
+	}
+
+	createdAssetClass, err := f.financeRepo.CreateAssetClass(ctx, assetClass)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":     "Asset class created successfully",
+			"asset_class": createdAssetClass,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateAssetClass(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	assetClassId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var assetClass entity.AssetClass
+	if err := helper.DecodeRequestBody(r, &assetClass); err != nil {
+		return nil, err
+	}
+	assetClass.ID = assetClassId
+
+	if err := validateAssetClass(&assetClass); err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.UpdateAssetClass(ctx, assetClass)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "asset class not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":     "Asset class updated successfully",
+			"asset_class": assetClass,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteAssetClass(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	assetClassId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.DeleteAssetClass(ctx, assetClassId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "asset class not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Asset class deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetAllocationTypes(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	allocationTypes, err := f.financeRepo.GetAllocationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []entity.AllocationTypeWithConfig{}
+	for _, allocationType := range allocationTypes {
+		config, err := f.financeRepo.GetAllocationConfigByAllocationTypeId(ctx, allocationType.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity.AllocationTypeWithConfig{
+			AllocationType: allocationType,
+			Config:         config,
+		})
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":          "Allocation types fetched successfully",
+			"allocation_types": result,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) CreateAllocationType(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var request entity.AllocationTypeWithConfig
+	if err := helper.DecodeRequestBody(r, &request); err != nil {
+		return nil, err
+	}
+
+	if err := validateAllocationType(&request.AllocationType); err != nil {
+		return nil, err
+	}
+	if err := f.validateAllocationTypeConfig(ctx, request.Config); err != nil {
+		return nil, err
+	}
+
+	// the new band has to fit between the existing ones
+	allocationTypes, err := f.financeRepo.GetAllocationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAgeBands(append(allocationTypes, request.AllocationType)); err != nil {
+		return nil, err
+	}
+
+	createdAllocationType, err := f.financeRepo.CreateAllocationType(ctx, request.AllocationType, request.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":         "Allocation type created successfully",
+			"allocation_type": entity.AllocationTypeWithConfig{AllocationType: *createdAllocationType, Config: request.Config},
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateAllocationType(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	allocationTypeId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var allocationType entity.AllocationType
+	if err := helper.DecodeRequestBody(r, &allocationType); err != nil {
+		return nil, err
+	}
+	allocationType.ID = allocationTypeId
+
+	if err := validateAllocationType(&allocationType); err != nil {
+		return nil, err
+	}
+
+	if _, err := f.mergeAllocationTypes(ctx, []entity.AllocationType{allocationType}); err != nil {
+		return nil, err
+	}
+
+	if _, err := f.financeRepo.UpdateAllocationTypes(ctx, []entity.AllocationType{allocationType}); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":         "Allocation type updated successfully",
+			"allocation_type": allocationType,
+		},
+		Success: true,
+	}, nil
+}
+
+// UpdateAllocationTypeAgeBands moves several age bands at once, which is needed to split or merge bands without a gap in between
+func (f FinanceUsecase) UpdateAllocationTypeAgeBands(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var request []entity.AllocationType
+	if err := helper.DecodeRequestBody(r, &request); err != nil {
+		return nil, err
+	}
+	if len(request) == 0 {
+		return nil, badRequest("at least one age band is required")
+	}
+
+	allocationTypes, err := f.financeRepo.GetAllocationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existingById := make(map[int64]entity.AllocationType)
+	for _, allocationType := range allocationTypes {
+		existingById[allocationType.ID] = allocationType
+	}
+
+	// only the age band is taken from the request
+	var updatedTypes []entity.AllocationType
+	for _, band := range request {
+		allocationType, ok := existingById[band.ID]
+		if !ok {
+			return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("allocation type %d not found", band.ID)}
+		}
+		allocationType.MinAge = band.MinAge
+		allocationType.MaxAge = band.MaxAge
+		updatedTypes = append(updatedTypes, allocationType)
+	}
+
+	mergedTypes, err := f.mergeAllocationTypes(ctx, updatedTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.financeRepo.UpdateAllocationTypes(ctx, updatedTypes); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":          "Allocation type age bands updated successfully",
+			"allocation_types": mergedTypes,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteAllocationType(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	allocationTypeId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	allocationTypes, err := f.financeRepo.GetAllocationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var remainingTypes []entity.AllocationType
+	for _, allocationType := range allocationTypes {
+		if allocationType.ID != allocationTypeId {
+			remainingTypes = append(remainingTypes, allocationType)
+		}
+	}
+	if len(remainingTypes) == len(allocationTypes) {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "allocation type not found"}
+	}
+
+	if err := validateAgeBands(remainingTypes); err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.DeleteAllocationType(ctx, allocationTypeId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "allocation type not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Allocation type deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateAllocationTypeConfig(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	allocationTypeId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var config []entity.AllocationTypeConfig
+	if err := helper.DecodeRequestBody(r, &config); err != nil {
+		return nil, err
+	}
+
+	if err := f.validateAllocationTypeConfig(ctx, config); err != nil {
+		return nil, err
+	}
+
+	allocationTypes, err := f.financeRepo.GetAllocationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found bool
+	for _, allocationType := range allocationTypes {
+		if allocationType.ID == allocationTypeId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "allocation type not found"}
+	}
+
+	if err := f.financeRepo.ReplaceAllocationTypeConfig(ctx, allocationTypeId, config); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Allocation type config updated successfully",
+			"config":  config,
+		},
+		Success: true,
+	}, nil
+}
+
+// mergeAllocationTypes replaces the stored allocation types with the updated ones and validates the resulting age bands
+func (f FinanceUsecase) mergeAllocationTypes(ctx context.Context, updatedTypes []entity.AllocationType) ([]entity.AllocationType, error) {
+	allocationTypes, err := f.financeRepo.GetAllocationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedById := make(map[int64]entity.AllocationType)
+	for _, allocationType := range updatedTypes {
+		updatedById[allocationType.ID] = allocationType
+	}
+
+	var mergedTypes []entity.AllocationType
+	for _, allocationType := range allocationTypes {
+		if updated, ok := updatedById[allocationType.ID]; ok {
+			allocationType = updated
+			delete(updatedById, allocationType.ID)
+		}
+		mergedTypes = append(mergedTypes, allocationType)
+	}
+
+	if len(updatedById) > 0 {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "allocation type not found"}
+	}
+
+	if err := validateAgeBands(mergedTypes); err != nil {
+		return nil, err
+	}
+
+	return mergedTypes, nil
+}
+
+func (f FinanceUsecase) validateAllocationTypeConfig(ctx context.Context, config []entity.AllocationTypeConfig) error {
+	if len(config) == 0 {
+		return badRequest("config is required")
+	}
+
+	assetClasses, err := f.financeRepo.GetAssetClass(ctx)
+	if err != nil {
+		return err
+	}
+
+	assetClassExists := make(map[int64]bool)
+	for _, assetClass := range assetClasses {
+		assetClassExists[assetClass.ID] = true
+	}
+
+	seen := make(map[int64]bool)
+	var total float64
+	for _, row := range config {
+		if !assetClassExists[row.AssetId] {
+			return badRequest(fmt.Sprintf("asset_id %d does not exist", row.AssetId))
+		}
+		if seen[row.AssetId] {
+			return badRequest(fmt.Sprintf("asset_id %d is repeated", row.AssetId))
+		}
+		seen[row.AssetId] = true
+
+		if row.AllocationInPercentage < 0 || row.AllocationInPercentage > constant.MaxPercentageAllowed {
+			return badRequest("allocation_in_percentage must be between 0 and 100")
+		}
+		total += row.AllocationInPercentage
+	}
+
+	if math.Abs(total-100) > constant.AllocationSumTolerance {
+		return badRequest(fmt.Sprintf("allocation_in_percentage must sum to 100, got %v", helper.RoundToDecimals(total, 2)))
+	}
+
+	return nil
+}
+
+// validateAgeBands makes sure every years left value from 0 up to the last band maps to exactly one allocation type
+func validateAgeBands(allocationTypes []entity.AllocationType) error {
+	if len(allocationTypes) == 0 {
+		return nil
+	}
+
+	bands := make([]entity.AllocationType, len(allocationTypes))
+	copy(bands, allocationTypes)
+	sort.SliceStable(bands, func(i, j int) bool {
+		return bands[i].MinAge < bands[j].MinAge
+	})
+
+	if bands[0].MinAge != 0 {
+		return badRequest("the first age band must start at 0")
+	}
+
+	for i := 1; i < len(bands); i++ {
+		previous, current := bands[i-1], bands[i]
+
+		if previous.MaxAge == nil || current.MinAge <= *previous.MaxAge {
+			return badRequest(fmt.Sprintf("age bands of %s and %s overlap", previous.Name, current.Name))
+		}
+		if current.MinAge > *previous.MaxAge+1 {
+			return badRequest(fmt.Sprintf("age bands of %s and %s leave a gap", previous.Name, current.Name))
+		}
+	}
+
+	// goals further away than the last band would have no allocation type
+	last := bands[len(bands)-1]
+	if last.MaxAge != nil && *last.MaxAge < constant.MaxGoalYearsLeft {
+		return badRequest(fmt.Sprintf("the last age band, %s, must have no max_age or one of at least %d", last.Name, constant.MaxGoalYearsLeft))
+	}
+
+	return nil
+}
+
+func validateAllocationType(allocationType *entity.AllocationType) error {
+	allocationType.Name = strings.TrimSpace(allocationType.Name)
+
+	if allocationType.Name == "" {
+		return badRequest("name is required")
+	}
+	if allocationType.MinAge < 0 {
+		return badRequest("min_age cannot be negative")
+	}
+	if allocationType.MaxAge != nil && *allocationType.MaxAge < allocationType.MinAge {
+		return badRequest("max_age cannot be less than min_age")
+	}
+
+	return nil
+}
+
+func validateAssetClass(assetClass *entity.AssetClass) error {
+	assetClass.Name = strings.TrimSpace(assetClass.Name)
+
+	if assetClass.Name == "" {
+		return badRequest("name is required")
+	}
+	if assetClass.ExpectedReturnInPercentage <= -100 || assetClass.ExpectedReturnInPercentage > constant.MaxPercentageAllowed {
+		return badRequest("expected_return_in_percentage must be greater than -100 and at most 100")
+	}
+	if assetClass.VolatilityInPercentage < 0 || assetClass.VolatilityInPercentage > constant.MaxPercentageAllowed {
+		return badRequest("volatility_in_percentage must be between 0 and 100")
+	}
+	if assetClass.STCGRateInPercentage < 0 || assetClass.STCGRateInPercentage > constant.MaxPercentageAllowed {
+		return badRequest("stcg_rate_in_percentage must be between 0 and 100")
+	}
+	if assetClass.LTCGRateInPercentage < 0 || assetClass.LTCGRateInPercentage > constant.MaxPercentageAllowed {
+		return badRequest("ltcg_rate_in_percentage must be between 0 and 100")
+	}
+	if assetClass.LTCGHoldingPeriodInMonths < 0 {
+		return badRequest("ltcg_holding_period_in_months cannot be negative")
+	}
+	if assetClass.LTCGExemptionLimit < 0 {
+		return badRequest("ltcg_exemption_limit cannot be negative")
+	}
+
+	return nil
+}
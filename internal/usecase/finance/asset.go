@@ -2,14 +2,18 @@ package finance
 
 import (
 	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
 	"master-finanacial-planner/internal/entity"
 	"master-finanacial-planner/internal/helper"
+	"master-finanacial-planner/internal/repo"
 	"net/http"
+	"strings"
 )
 
 func (f FinanceUsecase) GetEffectiveReturnAllocationType(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
 
-	result, err := f.getAllocationTypeReturns(ctx)
+	rawReturns, effectiveReturns, blends, err := f.getRawAndBlendedAllocationTypeReturns(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -17,37 +21,84 @@ func (f FinanceUsecase) GetEffectiveReturnAllocationType(ctx context.Context, r
 	return &entity.ApiResponse{
 		Data: map[string]interface{}{
 			"message":           "Asset class data fetched successfully",
-			"effective-returns": result,
+			"effective-returns": effectiveReturns,
+			"raw-returns":       rawReturns,
+			"blends":            blends,
 		},
 		Success: true,
 	}, nil
 
 }
 
+// getAllocationTypeReturns returns the effective return of every allocation type, blends applied
 func (f FinanceUsecase) getAllocationTypeReturns(ctx context.Context) (map[string]float64, error) {
-	data, err := f.financeRepo.GetAllAllocationTypeConfig(ctx)
+	_, effectiveReturns, _, err := f.getRawAndBlendedAllocationTypeReturns(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return effectiveReturns, nil
+}
+
+// getRawAndBlendedAllocationTypeReturns computes the raw return of every allocation type from its asset split,
+// then replaces the return of the blended allocation types by the weighted raw returns of their components
+func (f FinanceUsecase) getRawAndBlendedAllocationTypeReturns(ctx context.Context) (map[string]float64, map[string]float64, []entity.AllocationTypeBlend, error) {
+	data, err := f.financeRepo.GetAllAllocationTypeConfig(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	blends, err := f.financeRepo.GetAllocationTypeBlends(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	result := make(map[string]float64)
+	rawReturns, effectiveReturns := blendAllocationTypeReturns(data, blends, func(row repo.AllocationTypeConfig) float64 {
+		return row.AssetReturns
+	})
+
+	return rawReturns, effectiveReturns, blends, nil
+}
+
+// blendAllocationTypeReturns weighs the asset class returns of every allocation type, assetReturn gives the return of one config row
+func blendAllocationTypeReturns(data []repo.AllocationTypeConfig, blends []entity.AllocationTypeBlend, assetReturn func(row repo.AllocationTypeConfig) float64) (map[string]float64, map[string]float64) {
+	rawReturns := make(map[string]float64)
 
 	for _, row := range data {
-		x := row.AssetReturns * row.AllocationInPercentage / 100
-		result[row.AllocationTypeName] += x
+		x := assetReturn(row) * row.AllocationInPercentage / 100
+		rawReturns[row.AllocationTypeName] += x
+	}
+
+	effectiveReturns := make(map[string]float64)
+	for k, v := range rawReturns {
+		effectiveReturns[k] = v
 	}
 
-	result["medium-term"] = result["short-term"]*0.6 + result["medium-term"]*0.4
+	// components always contribute their raw return, blends are not chained
+	blendedReturns := make(map[string]float64)
+	for _, blend := range blends {
+		blendedReturns[blend.AllocationTypeName] += rawReturns[blend.ComponentAllocationTypeName] * blend.WeightInPercentage / 100
+	}
+	for k, v := range blendedReturns {
+		effectiveReturns[k] = v
+	}
 
-	for k, v := range result {
-		result[k] = helper.RoundToDecimals(v, 1)
+	for k, v := range rawReturns {
+		rawReturns[k] = helper.RoundToDecimals(v, 1)
+	}
+	for k, v := range effectiveReturns {
+		effectiveReturns[k] = helper.RoundToDecimals(v, 1)
 	}
 
-	return result, nil
+	return rawReturns, effectiveReturns
 }
 
 func (f FinanceUsecase) GetInvestingSurplus(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
-	data, err := f.financeRepo.GetInvestingSurplus(ctx)
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := f.financeRepo.GetInvestingSurplus(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -64,14 +115,19 @@ func (f FinanceUsecase) GetInvestingSurplus(ctx context.Context, r *http.Request
 
 func (f FinanceUsecase) GetNetWorth(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
 
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// liquid and Illiquid
-	data, err := f.financeRepo.GetLiquidAndIlliquidAssets(ctx)
+	data, err := f.financeRepo.GetLiquidAndIlliquidAssets(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
 
-	// liabilities
-	liabilitiesAmount, err := f.financeRepo.GetAllLiability(ctx)
+	// liabilities, loans count with their outstanding principal as of today
+	liabilitiesAmount, err := f.getOutstandingLiabilities(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +138,7 @@ func (f FinanceUsecase) GetNetWorth(ctx context.Context, r *http.Request) (*enti
 	for k, v := range data {
 		totalAsset += v
 
-		if k == "liquid" {
+		if k == constant.LiquidityTypeLiquid {
 			liquidAsset += v
 		}
 	}
@@ -94,6 +150,7 @@ func (f FinanceUsecase) GetNetWorth(ctx context.Context, r *http.Request) (*enti
 			"message":      "Net Worth info fetched successfully",
 			"total_asset":  totalAsset,
 			"liquid_asset": liquidAsset,
+			"liabilities":  liabilitiesAmount,
 			"net_worth":    netWorth,
 		},
 		Success: true,
@@ -116,52 +173,160 @@ func (f FinanceUsecase) GetAssetClass(ctx context.Context, r *http.Request) (*en
 	}, nil
 }
 
+// SipAllocator splits the sip of every goal into asset classes. When the investing surplus cannot cover every goal,
+// the policy query param decides who is funded first: priority (default) or pro-rata.
+// The surplus_mode query param picks today's surplus (current, default) or the average of the projected next 12 months.
+// The return_mode query param plans with pre-tax (default) or post-tax returns, after capital gains tax at the goal's horizon
 func (f FinanceUsecase) SipAllocator(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
 
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := constant.SIPFundingPolicyPriority
+	if value := r.URL.Query().Get("policy"); value != "" {
+		policy = strings.ToLower(value)
+		if policy != constant.SIPFundingPolicyPriority && policy != constant.SIPFundingPolicyProRata {
+			return nil, badRequest(fmt.Sprintf("policy must be one of %s, %s", constant.SIPFundingPolicyPriority, constant.SIPFundingPolicyProRata))
+		}
+	}
+
+	surplusMode := constant.SurplusModeCurrent
+	if value := r.URL.Query().Get("surplus_mode"); value != "" {
+		surplusMode = strings.ToLower(value)
+		if surplusMode != constant.SurplusModeCurrent && surplusMode != constant.SurplusModeProjected {
+			return nil, badRequest(fmt.Sprintf("surplus_mode must be one of %s, %s", constant.SurplusModeCurrent, constant.SurplusModeProjected))
+		}
+	}
+
+	returnMode := constant.ReturnModePreTax
+	if value := r.URL.Query().Get("return_mode"); value != "" {
+		returnMode = strings.ToLower(value)
+		if returnMode != constant.ReturnModePreTax && returnMode != constant.ReturnModePostTax {
+			return nil, badRequest(fmt.Sprintf("return_mode must be one of %s, %s", constant.ReturnModePreTax, constant.ReturnModePostTax))
+		}
+	}
+
 	// get the goal
-	goalsData, err := f.financeRepo.GetGoals(ctx)
+	goalsData, err := f.financeRepo.GetGoals(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the top-up goal is funded first, at today's gap
+	goalsData, err = f.withEmergencyFundGoal(ctx, userId, goalsData)
+	if err != nil {
+		return nil, err
+	}
+
+	investingSurplus, err := f.getInvestingSurplus(ctx, userId, surplusMode)
 	if err != nil {
 		return nil, err
 	}
 
 	var sipAllocator = make(map[string]float64)
+	var sipAllocatorByAssetId = make(map[int64]float64)
+	var assetNameById = make(map[int64]string)
+
+	bands, err := f.getGlidePathBands(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var postTaxInputs *postTaxReturnInputs
+	if returnMode == constant.ReturnModePostTax {
+		postTaxInputs, err = f.getPostTaxReturnInputs(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	goalsById := make(map[int64]entity.Goals)
+	glidePathsById := make(map[int64]*entity.GoalGlidePath)
+	goalFunding := []entity.GoalFunding{}
 
 	// for each goal
 	for _, goal := range goalsData {
 
-		// calculate the inflated amount
-		inflatedAmount := helper.InflationCalculator(goal.TodayAmount, goal.YearsLeft, goal.InflationPercentage)
-		// subtract the allocated amount
-		requiredAmount := inflatedAmount - goal.AllocatedAmount
-
-		// calculate the sip required
-		if requiredAmount <= 0 {
+		// due goals have no time left to invest for
+		if goal.IsDue {
 			continue
 		}
 
-		allocationTypeReturnsMap, err := f.getAllocationTypeReturns(ctx)
-		if err != nil {
-			return nil, err
+		// the sip required follows the allocation type bands the goal moves through till it is due
+		goalBands := bands
+		if postTaxInputs != nil {
+			goalBands = postTaxInputs.postTaxBands(bands, goal)
 		}
 
-		sipRequired := helper.CalculateSIPRequired(requiredAmount, goal.YearsLeft, allocationTypeReturnsMap[goal.Name], goal.SIPStepUpPercentage)
-
-		allocationConfigData, err := f.getAllocationTypeConfigByYearLeft(ctx, goal.YearsLeft)
+		glidePath, err := projectGoalGlidePath(goal, goalBands)
 		if err != nil {
 			return nil, err
 		}
 
+		if glidePath.GlidePathSIP <= 0 {
+			continue
+		}
+
+		goalsById[goal.ID] = goal
+		glidePathsById[goal.ID] = glidePath
+		goalFunding = append(goalFunding, entity.GoalFunding{
+			GoalId:       goal.ID,
+			GoalName:     goal.Name,
+			Priority:     goal.Priority,
+			TargetAmount: glidePath.TargetAmount,
+			RequiredSIP:  glidePath.GlidePathSIP,
+		})
+	}
+
+	fundGoals(goalFunding, investingSurplus, policy)
+
+	var totalRequiredSIP, totalFundedSIP float64
+	for i, funding := range goalFunding {
+		projectFundedGoal(&goalFunding[i], goalsById[funding.GoalId], glidePathsById[funding.GoalId])
+		totalRequiredSIP += funding.RequiredSIP
+		totalFundedSIP += funding.FundedSIP
+
+		// today's sip is invested as per the current band
+		allocationConfigData := glidePathsById[funding.GoalId].Path[0].AssetSplit
+
 		// divide the sip amount according to the asset class
 		for _, assetAllocationInfo := range allocationConfigData {
-			sipAllocator[assetAllocationInfo.AssetName] += sipRequired * assetAllocationInfo.AllocationInPercentage / 100
+			assetSip := funding.FundedSIP * assetAllocationInfo.AllocationInPercentage / 100
+			sipAllocator[assetAllocationInfo.AssetName] += assetSip
+			sipAllocatorByAssetId[assetAllocationInfo.AssetId] += assetSip
+			assetNameById[assetAllocationInfo.AssetId] = assetAllocationInfo.AssetName
 		}
 
 	}
 
+	// divide each asset class sip further into its sub categories
+	subCategoriesByAsset, err := f.getSubCategoriesByAssetClass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var subCategoryAllocator = make(map[string][]entity.SubCategoryAllocation)
+	for assetId, assetSip := range sipAllocatorByAssetId {
+		if len(subCategoriesByAsset[assetId]) == 0 {
+			continue
+		}
+		subCategoryAllocator[assetNameById[assetId]] = splitBySubCategory(assetSip, subCategoriesByAsset[assetId])
+	}
+
 	return &entity.ApiResponse{
 		Data: map[string]interface{}{
-			"message":       "SIP allocation fetched successfully",
-			"Sip Allocator": sipAllocator,
+			"message":                "SIP allocation fetched successfully",
+			"Sip Allocator":          sipAllocator,
+			"sub_category_allocator": subCategoryAllocator,
+			"policy":                 policy,
+			"surplus_mode":           surplusMode,
+			"return_mode":            returnMode,
+			"investing_surplus":      investingSurplus,
+			"total_required_sip":     helper.RoundToDecimals(totalRequiredSIP, 2),
+			"total_funded_sip":       helper.RoundToDecimals(totalFundedSIP, 2),
+			"goal_funding":           goalFunding,
 		},
 		Success: true,
 	}, nil
@@ -174,6 +339,9 @@ func (f FinanceUsecase) getAllocationTypeConfigByYearLeft(ctx context.Context, y
 	if err != nil {
 		return nil, err
 	}
+	if len(allocationData) == 0 {
+		return nil, &entity.CustomError{StatusCode: http.StatusUnprocessableEntity, Message: fmt.Sprintf("no allocation type is configured for %d years left", yearleft)}
+	}
 
 	// get allocation type config
 	allocationConfigData, err := f.financeRepo.GetAllocationConfigByAllocationTypeId(ctx, allocationData[0].ID)
@@ -187,20 +355,64 @@ func (f FinanceUsecase) getAllocationTypeConfigByYearLeft(ctx context.Context, y
 
 func (f FinanceUsecase) GetInvestableAssetAllocation(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
 
-	// get current Investable Allocation
-	currentInvestableArr, err := f.financeRepo.GetCurrentInvestableData(ctx)
+	userId, err := helper.GetUserIdFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var requiredInvestableAssetAllocator = make(map[int64]entity.InvestableAssetAllocation)
+	// get current Investable Allocation
+	currentInvestableArr, err := f.financeRepo.GetCurrentInvestableData(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
 
 	// get the goal
-	goalsData, err := f.financeRepo.GetGoals(ctx)
+	goalsData, err := f.financeRepo.GetGoals(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredInvestableAssetArr, err := f.getRequiredInvestableAssetAllocation(ctx, goalsData)
+	if err != nil {
+		return nil, err
+	}
+
+	investableAssetAllocation := reduceToAPIResponse(requiredInvestableAssetArr, currentInvestableArr)
+
+	// split the current and required value of each asset class into sub categories
+	subCategoriesByAsset, err := f.getSubCategoriesByAssetClass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, err := f.financeRepo.GetSubCategoryHoldings(ctx, userId, true)
 	if err != nil {
 		return nil, err
 	}
 
+	for i := range investableAssetAllocation {
+		investableAssetAllocation[i].SubCategories = reduceSubCategoryAllocation(
+			investableAssetAllocation[i],
+			subCategoriesByAsset[investableAssetAllocation[i].AssetId],
+			holdings,
+		)
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":                      "Investable asset allocation fetched successfully",
+			"investable-assets-allocation": investableAssetAllocation,
+		},
+		Success: true,
+	}, nil
+
+}
+
+// getRequiredInvestableAssetAllocation splits the allocated amount of every goal as per its allocation type
+func (f FinanceUsecase) getRequiredInvestableAssetAllocation(ctx context.Context, goalsData []entity.Goals) ([]entity.InvestableAssetAllocation, error) {
+
+	var requiredInvestableAssetAllocator = make(map[int64]entity.InvestableAssetAllocation)
+
 	var totalRequiredAmount float64
 	// for each goal
 	for _, goal := range goalsData {
@@ -240,14 +452,7 @@ func (f FinanceUsecase) GetInvestableAssetAllocation(ctx context.Context, r *htt
 		requiredInvestableAssetArr = append(requiredInvestableAssetArr, v)
 	}
 
-	return &entity.ApiResponse{
-		Data: map[string]interface{}{
-			"message":                      "Investable asset allocation fetched successfully",
-			"investable-assets-allocation": reduceToAPIResponse(requiredInvestableAssetArr, currentInvestableArr),
-		},
-		Success: true,
-	}, nil
-
+	return requiredInvestableAssetArr, nil
 }
 
 func reduceToAPIResponse(
@@ -286,3 +491,44 @@ func reduceToAPIResponse(
 
 	return InvestableAssetAllocationResponses
 }
+
+// reduceSubCategoryAllocation pairs the liquid holdings of each sub category with its share of the required value
+func reduceSubCategoryAllocation(
+	assetAllocation entity.InvestableAssetAllocationAPIResponse,
+	subCategories []entity.AssetSubCategory,
+	holdings []entity.SubCategoryHolding,
+) []entity.InvestableSubCategoryAllocation {
+
+	result := []entity.InvestableSubCategoryAllocation{}
+	indexBySubCategoryId := make(map[int64]int)
+
+	for _, required := range splitBySubCategory(assetAllocation.Required.Value, subCategories) {
+		indexBySubCategoryId[required.SubCategoryId] = len(result)
+		result = append(result, entity.InvestableSubCategoryAllocation{
+			SubCategoryId:   required.SubCategoryId,
+			SubCategoryName: required.SubCategoryName,
+			PriorityOrder:   required.PriorityOrder,
+			RequiredValue:   required.Value,
+		})
+	}
+
+	for _, holding := range holdings {
+		if holding.AssetId != assetAllocation.AssetId || holding.LiquidValue == 0 {
+			continue
+		}
+
+		index, ok := indexBySubCategoryId[holding.SubCategoryId]
+		if !ok {
+			// holdings without a sub category are still reported
+			index = len(result)
+			indexBySubCategoryId[holding.SubCategoryId] = index
+			result = append(result, entity.InvestableSubCategoryAllocation{
+				SubCategoryId:   holding.SubCategoryId,
+				SubCategoryName: holding.SubCategoryName,
+			})
+		}
+		result[index].CurrentValue += holding.LiquidValue
+	}
+
+	return result
+}
@@ -0,0 +1,370 @@
+package finance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (f FinanceUsecase) GetCashflowRules(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := f.financeRepo.GetCashflowRules(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Cashflow rules fetched successfully",
+			"rules":   rules,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) CreateCashflowRule(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule entity.CashflowRule
+	if err := helper.DecodeRequestBody(r, &rule); err != nil {
+		return nil, err
+	}
+
+	if err := validateCashflowRule(&rule); err != nil {
+		return nil, err
+	}
+
+	createdRule, err := f.financeRepo.CreateCashflowRule(ctx, userId, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Cashflow rule created successfully",
+			"rule":    createdRule,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateCashflowRule(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var rule entity.CashflowRule
+	if err := helper.DecodeRequestBody(r, &rule); err != nil {
+		return nil, err
+	}
+	rule.ID = ruleId
+
+	if err := validateCashflowRule(&rule); err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.UpdateCashflowRule(ctx, userId, rule)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "cashflow rule not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Cashflow rule updated successfully",
+			"rule":    rule,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteCashflowRule(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.DeleteCashflowRule(ctx, userId, ruleId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "cashflow rule not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Cashflow rule deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+// ImportBankStatement reads the multipart "file" csv using the column mapping json in the "mapping" field.
+// Rows are categorised by the user's rules, rows already imported are skipped, and the bank_import cashflows
+// are derived again from the recurring categories of the latest months
+func (f FinanceUsecase) ImportBankStatement(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := helper.ParseMultipartUpload(r, constant.MaxBankStatementUploadSizeInBytes); err != nil {
+		return nil, err
+	}
+
+	var mapping entity.BankStatementMapping
+	if err := json.Unmarshal([]byte(r.FormValue("mapping")), &mapping); err != nil {
+		return nil, badRequest("mapping must be a json column mapping")
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, badRequest("file is required")
+	}
+	defer file.Close()
+
+	transactions, skipped, err := helper.ParseBankStatementCSV(file, mapping)
+	if err != nil {
+		return nil, badRequest(err.Error())
+	}
+	if len(transactions) == 0 {
+		return nil, badRequest("no transaction found in the statement")
+	}
+
+	rules, err := f.financeRepo.GetCashflowRules(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	categorise := cashflowCategoriser(rules)
+
+	// identical rows in one statement are real repeated transactions, the occurrence keeps their fingerprints apart
+	occurrences := make(map[string]int)
+	latest := transactions[0].TransactionDate.Time
+	for i := range transactions {
+		transactions[i].Category = categorise(transactions[i].Narration)
+
+		key := helper.BankTransactionFingerprint(transactions[i], 0)
+		transactions[i].Fingerprint = helper.BankTransactionFingerprint(transactions[i], occurrences[key])
+		occurrences[key]++
+
+		if transactions[i].TransactionDate.After(latest) {
+			latest = transactions[i].TransactionDate.Time
+		}
+	}
+
+	imported, duplicates, err := f.financeRepo.CreateBankTransactions(ctx, userId, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Date(latest.Year(), latest.Month()-constant.RecurringCashflowLookbackMonths+1, 1, 0, 0, 0, 0, time.UTC)
+	recent, err := f.financeRepo.GetBankTransactions(ctx, userId, since)
+	if err != nil {
+		return nil, err
+	}
+
+	cashflows, err := f.financeRepo.GetCashflows(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	recurring, overlaps := withoutManualOverlaps(deriveRecurringCashflows(recent), cashflows)
+
+	derived, err := f.financeRepo.ReplaceBankImportCashflows(ctx, userId, recurring)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Bank statement imported successfully",
+			"result": entity.BankImportResult{
+				Imported:         imported,
+				Duplicates:       duplicates,
+				SkippedRows:      skipped,
+				DerivedCashflows: derived,
+				ManualOverlaps:   overlaps,
+			},
+		},
+		Success: true,
+	}, nil
+}
+
+// cashflowCategoriser returns the category of the first rule matching a narration, rules come sorted by priority
+func cashflowCategoriser(rules []entity.CashflowRule) func(narration string) string {
+	type compiledRule struct {
+		pattern  *regexp.Regexp
+		category string
+	}
+
+	compiled := []compiledRule{}
+	for _, rule := range rules {
+		pattern, err := regexp.Compile("(?i)" + rule.Pattern)
+		if err != nil {
+			continue
+		}
+		compiled = append(compiled, compiledRule{pattern: pattern, category: rule.Category})
+	}
+
+	return func(narration string) string {
+		for _, rule := range compiled {
+			if rule.pattern.MatchString(narration) {
+				return rule.category
+			}
+		}
+		return constant.CashflowCategoryOther
+	}
+}
+
+// deriveRecurringCashflows turns the transactions of the last RecurringCashflowLookbackMonths months into monthly cashflows.
+// A category and direction is recurring when it shows up in at least RecurringCashflowMinMonths months,
+// its amount is the average over the months the statements cover
+func deriveRecurringCashflows(transactions []entity.BankTransaction) []entity.Cashflow {
+	if len(transactions) == 0 {
+		return []entity.Cashflow{}
+	}
+
+	latest := transactions[0].TransactionDate.Time
+	for _, transaction := range transactions {
+		if transaction.TransactionDate.After(latest) {
+			latest = transaction.TransactionDate.Time
+		}
+	}
+	windowStart := time.Date(latest.Year(), latest.Month()-constant.RecurringCashflowLookbackMonths+1, 1, 0, 0, 0, 0, time.UTC)
+
+	type flowKey struct {
+		category string
+		isInflow bool
+	}
+	totals := make(map[flowKey]float64)
+	months := make(map[flowKey]map[string]bool)
+	coveredMonths := make(map[string]bool)
+
+	for _, transaction := range transactions {
+		if transaction.TransactionDate.Before(windowStart) {
+			continue
+		}
+		month := transaction.TransactionDate.Format("2006-01")
+		key := flowKey{category: transaction.Category, isInflow: transaction.IsInflow}
+
+		totals[key] += transaction.Amount
+		if months[key] == nil {
+			months[key] = make(map[string]bool)
+		}
+		months[key][month] = true
+		coveredMonths[month] = true
+	}
+
+	// keep the categories in their declared order, inflows first
+	cashflows := []entity.Cashflow{}
+	for _, isInflow := range []bool{true, false} {
+		for _, category := range constant.CashflowCategories {
+			key := flowKey{category: category, isInflow: isInflow}
+			if len(months[key]) < constant.RecurringCashflowMinMonths {
+				continue
+			}
+
+			direction := "outflow"
+			if isInflow {
+				direction = "inflow"
+			}
+			cashflows = append(cashflows, entity.Cashflow{
+				Name:      fmt.Sprintf("Bank import: %s %s", category, direction),
+				Amount:    helper.RoundToDecimals(totals[key]/float64(len(coveredMonths)), 2),
+				IsInflow:  isInflow,
+				Category:  category,
+				Source:    constant.CashflowSourceBankImport,
+				Frequency: constant.CashflowFrequencyMonthly,
+			})
+		}
+	}
+
+	return cashflows
+}
+
+// withoutManualOverlaps leaves out the derived cashflows whose category and direction the user already keeps
+// as a manual cashflow, so they are not counted twice in the surplus. The overlaps are returned as "<category> <direction>"
+func withoutManualOverlaps(derived []entity.Cashflow, cashflows []entity.Cashflow) ([]entity.Cashflow, []string) {
+	type flowKey struct {
+		category string
+		isInflow bool
+	}
+	manual := make(map[flowKey]bool)
+	for _, cashflow := range cashflows {
+		if cashflow.Source == constant.CashflowSourceManual {
+			manual[flowKey{category: cashflow.Category, isInflow: cashflow.IsInflow}] = true
+		}
+	}
+
+	kept := []entity.Cashflow{}
+	overlaps := []string{}
+	for _, cashflow := range derived {
+		if !manual[flowKey{category: cashflow.Category, isInflow: cashflow.IsInflow}] {
+			kept = append(kept, cashflow)
+			continue
+		}
+		direction := "outflow"
+		if cashflow.IsInflow {
+			direction = "inflow"
+		}
+		overlaps = append(overlaps, fmt.Sprintf("%s %s", cashflow.Category, direction))
+	}
+
+	return kept, overlaps
+}
+
+func validateCashflowRule(rule *entity.CashflowRule) error {
+	rule.Pattern = strings.TrimSpace(rule.Pattern)
+	rule.Category = strings.ToLower(strings.TrimSpace(rule.Category))
+
+	if rule.Pattern == "" {
+		return badRequest("pattern is required")
+	}
+	if _, err := regexp.Compile(rule.Pattern); err != nil {
+		return badRequest("pattern must be a valid regular expression")
+	}
+	if !slices.Contains(constant.CashflowCategories, rule.Category) {
+		return badRequest("category must be one of " + strings.Join(constant.CashflowCategories, ", "))
+	}
+	if rule.Priority == 0 {
+		rule.Priority = constant.DefaultCashflowRulePriority
+	}
+	if rule.Priority < 1 {
+		return badRequest("priority must be at least 1")
+	}
+
+	return nil
+}
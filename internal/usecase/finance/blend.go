@@ -0,0 +1,95 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"math"
+	"net/http"
+)
+
+func (f FinanceUsecase) GetAllocationTypeBlends(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	blends, err := f.financeRepo.GetAllocationTypeBlends(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Allocation type blends fetched successfully",
+			"blends":  blends,
+		},
+		Success: true,
+	}, nil
+}
+
+// UpdateAllocationTypeBlend replaces the blend of the allocation type, an empty list makes it use its raw return again
+func (f FinanceUsecase) UpdateAllocationTypeBlend(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	allocationTypeId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var blends []entity.AllocationTypeBlend
+	if err := helper.DecodeRequestBody(r, &blends); err != nil {
+		return nil, err
+	}
+
+	allocationTypes, err := f.financeRepo.GetAllocationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allocationTypeNames := make(map[int64]string)
+	for _, allocationType := range allocationTypes {
+		allocationTypeNames[allocationType.ID] = allocationType.Name
+	}
+
+	if _, ok := allocationTypeNames[allocationTypeId]; !ok {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "allocation type not found"}
+	}
+
+	if len(blends) > 0 {
+		seen := make(map[int64]bool)
+		var total float64
+		for i, blend := range blends {
+			name, ok := allocationTypeNames[blend.ComponentAllocationTypeId]
+			if !ok {
+				return nil, badRequest(fmt.Sprintf("component_allocation_type_id %d does not exist", blend.ComponentAllocationTypeId))
+			}
+			if seen[blend.ComponentAllocationTypeId] {
+				return nil, badRequest(fmt.Sprintf("component_allocation_type_id %d is repeated", blend.ComponentAllocationTypeId))
+			}
+			seen[blend.ComponentAllocationTypeId] = true
+
+			if blend.WeightInPercentage <= 0 || blend.WeightInPercentage > constant.MaxPercentageAllowed {
+				return nil, badRequest("weight_in_percentage must be greater than 0 and at most 100")
+			}
+			total += blend.WeightInPercentage
+
+			blends[i].AllocationTypeId = allocationTypeId
+			blends[i].AllocationTypeName = allocationTypeNames[allocationTypeId]
+			blends[i].ComponentAllocationTypeName = name
+		}
+
+		if math.Abs(total-100) > constant.AllocationSumTolerance {
+			return nil, badRequest(fmt.Sprintf("weight_in_percentage must sum to 100, got %v", helper.RoundToDecimals(total, 2)))
+		}
+	}
+
+	if err := f.financeRepo.ReplaceAllocationTypeBlend(ctx, allocationTypeId, blends); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Allocation type blend updated successfully",
+			"blends":  blends,
+		},
+		Success: true,
+	}, nil
+}
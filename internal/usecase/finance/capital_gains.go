@@ -0,0 +1,308 @@
+package finance
+
+import (
+	"context"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// holdingLedgers is every holding of the user with a ledger, and the tax profile of the asset classes
+type holdingLedgers struct {
+	investments    []entity.Investment
+	ledgers        map[int64][]entity.InvestmentTransaction
+	profiles       map[int64]entity.CapitalGainsTaxProfile
+	exemptionLimit float64
+}
+
+// GetCapitalGainsReport lists the gains realised during a financial year (query param, default the current one),
+// sells are matched to purchases first in first out. Asset classes with an ltcg exemption share one yearly allowance,
+// the largest one configured, like equity funds and shares do
+func (f FinanceUsecase) GetCapitalGainsReport(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	financialYear := r.URL.Query().Get("financial_year")
+	if financialYear == "" {
+		financialYear = helper.FinancialYearOf(time.Now())
+	}
+	start, end, err := helper.FinancialYearRange(financialYear)
+	if err != nil {
+		return nil, badRequest(err.Error())
+	}
+
+	holdings, err := f.getHoldingLedgers(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	gains := holdings.realisedGains(start, end)
+
+	assetClasses := []entity.AssetClassCapitalGains{}
+	assetClassIndexById := make(map[int64]int)
+	var shortTermGain, longTermGain, exemptEligibleGain float64
+	for _, gain := range gains {
+		index, ok := assetClassIndexById[gain.AssetId]
+		if !ok {
+			index = len(assetClasses)
+			assetClassIndexById[gain.AssetId] = index
+			assetClasses = append(assetClasses, entity.AssetClassCapitalGains{AssetId: gain.AssetId, AssetName: gain.AssetName})
+		}
+
+		if gain.Term == constant.CapitalGainTermShort {
+			assetClasses[index].ShortTermGain += gain.Gain
+			shortTermGain += gain.Gain
+			continue
+		}
+		assetClasses[index].LongTermGain += gain.Gain
+		longTermGain += gain.Gain
+		if holdings.profiles[gain.AssetId].LTCGExemptionLimit > 0 {
+			exemptEligibleGain += gain.Gain
+		}
+	}
+
+	// the allowance is set off against the eligible classes in the order they show up
+	remainingExemption := holdings.exemptionLimit
+	var estimatedTax float64
+	for i := range assetClasses {
+		profile := holdings.profiles[assetClasses[i].AssetId]
+
+		taxableLongTerm := math.Max(assetClasses[i].LongTermGain, 0)
+		if profile.LTCGExemptionLimit > 0 {
+			assetClasses[i].ExemptGain = math.Min(taxableLongTerm, remainingExemption)
+			remainingExemption -= assetClasses[i].ExemptGain
+			taxableLongTerm -= assetClasses[i].ExemptGain
+		}
+
+		tax := math.Max(assetClasses[i].ShortTermGain, 0)*profile.STCGRateInPercentage/100 + taxableLongTerm*profile.LTCGRateInPercentage/100
+		estimatedTax += tax
+
+		assetClasses[i].ShortTermGain = helper.RoundToDecimals(assetClasses[i].ShortTermGain, 2)
+		assetClasses[i].LongTermGain = helper.RoundToDecimals(assetClasses[i].LongTermGain, 2)
+		assetClasses[i].ExemptGain = helper.RoundToDecimals(assetClasses[i].ExemptGain, 2)
+		assetClasses[i].EstimatedTax = helper.RoundToDecimals(tax, 2)
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":         "Capital gains report fetched successfully",
+			"financial_year":  financialYear,
+			"short_term_gain": helper.RoundToDecimals(shortTermGain, 2),
+			"long_term_gain":  helper.RoundToDecimals(longTermGain, 2),
+			"exemption_limit": holdings.exemptionLimit,
+			"exemption_used":  helper.RoundToDecimals(math.Min(math.Max(exemptEligibleGain, 0), holdings.exemptionLimit), 2),
+			"estimated_tax":   helper.RoundToDecimals(estimatedTax, 2),
+			"asset_classes":   assetClasses,
+			"realised_gains":  gains,
+		},
+		Success: true,
+	}, nil
+}
+
+// GetTaxHarvestingSuggestions lists the long term lots to sell and buy back this financial year so their gains use up
+// what is left of the ltcg exemption without going over it. Units of a holding are sold first in first out,
+// so a holding is walked from its oldest lot and stops at the first short term one
+func (f FinanceUsecase) GetTaxHarvestingSuggestions(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start, end, err := helper.FinancialYearRange(helper.FinancialYearOf(now))
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, err := f.getHoldingLedgers(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var realisedEligibleGain float64
+	for _, gain := range holdings.realisedGains(start, end) {
+		if gain.Term == constant.CapitalGainTermLong && holdings.profiles[gain.AssetId].LTCGExemptionLimit > 0 {
+			realisedEligibleGain += gain.Gain
+		}
+	}
+	remainingExemption := math.Max(holdings.exemptionLimit-math.Max(realisedEligibleGain, 0), 0)
+
+	type candidate struct {
+		investment entity.Investment
+		lots       []entity.TaxLot
+		unitPrice  float64
+		gainRatio  float64
+	}
+
+	candidates := []candidate{}
+	for _, investment := range holdings.investments {
+		profile := holdings.profiles[investment.AssetId]
+		if profile.LTCGExemptionLimit == 0 {
+			continue
+		}
+
+		lots, _ := helper.MatchFIFOLots(holdings.ledgers[investment.ID])
+		var units, cost float64
+		for _, lot := range lots {
+			units += lot.Units
+			cost += lot.Cost
+		}
+		if units <= 0 || investment.Amount <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, candidate{
+			investment: investment,
+			lots:       lots,
+			unitPrice:  investment.Amount / units,
+			gainRatio:  (investment.Amount - cost) / investment.Amount,
+		})
+	}
+
+	// holdings with the most gain per rupee sold need the least churn to use the exemption
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].gainRatio > candidates[j].gainRatio
+	})
+
+	harvestLots := []entity.HarvestLot{}
+	var harvestedGain, saleValue float64
+	for _, candidate := range candidates {
+		if remainingExemption-harvestedGain <= 0 {
+			break
+		}
+		profile := holdings.profiles[candidate.investment.AssetId]
+
+		for _, lot := range candidate.lots {
+			if !helper.IsLongTerm(lot.PurchaseDate.Time, now, profile.LTCGHoldingPeriodInMonths) {
+				break
+			}
+
+			units := lot.Units
+			gain := units*candidate.unitPrice - lot.Cost
+			// selling a loss lot only wastes the exemption, and fifo sells it before any later lot
+			if gain <= 0 {
+				break
+			}
+			headroom := remainingExemption - harvestedGain
+			if gain > headroom {
+				// only the first units of the lot fit, rounded down so the gain stays within the exemption
+				units = math.Floor(headroom/(candidate.unitPrice-lot.Cost/lot.Units)*10000) / 10000
+				if units <= 0 {
+					break
+				}
+			}
+
+			cost := lot.Cost * units / lot.Units
+			marketValue := units * candidate.unitPrice
+			harvestLots = append(harvestLots, entity.HarvestLot{
+				InvestmentId:   candidate.investment.ID,
+				InvestmentName: candidate.investment.Name,
+				AssetName:      candidate.investment.AssetName,
+				PurchaseDate:   lot.PurchaseDate,
+				Units:          helper.RoundToDecimals(units, 4),
+				Cost:           helper.RoundToDecimals(cost, 2),
+				MarketValue:    helper.RoundToDecimals(marketValue, 2),
+				Gain:           helper.RoundToDecimals(marketValue-cost, 2),
+			})
+			harvestedGain += marketValue - cost
+			saleValue += marketValue
+
+			if units < lot.Units {
+				break
+			}
+		}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":             "Tax harvesting suggestions fetched successfully",
+			"financial_year":      helper.FinancialYearOf(now),
+			"exemption_limit":     holdings.exemptionLimit,
+			"realised_ltcg":       helper.RoundToDecimals(realisedEligibleGain, 2),
+			"remaining_exemption": helper.RoundToDecimals(remainingExemption, 2),
+			"harvested_gain":      helper.RoundToDecimals(harvestedGain, 2),
+			"sale_value":          helper.RoundToDecimals(saleValue, 2),
+			"lots":                harvestLots,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) getHoldingLedgers(ctx context.Context, userId int64) (*holdingLedgers, error) {
+	investments, err := f.financeRepo.GetInvestments(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := f.financeRepo.GetInvestmentTransactions(ctx, userId, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	assetClasses, err := f.financeRepo.GetAssetClass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := &holdingLedgers{
+		investments: investments,
+		ledgers:     make(map[int64][]entity.InvestmentTransaction),
+		profiles:    make(map[int64]entity.CapitalGainsTaxProfile),
+	}
+	for _, transaction := range transactions {
+		holdings.ledgers[transaction.InvestmentId] = append(holdings.ledgers[transaction.InvestmentId], transaction)
+	}
+	for _, assetClass := range assetClasses {
+		holdings.profiles[assetClass.ID] = assetClass.CapitalGainsTaxProfile
+		holdings.exemptionLimit = math.Max(holdings.exemptionLimit, assetClass.LTCGExemptionLimit)
+	}
+
+	return holdings, nil
+}
+
+// realisedGains returns the matched sells between the dates, classified by the holding period of the asset class
+func (h holdingLedgers) realisedGains(start time.Time, end time.Time) []entity.RealisedGain {
+	gains := []entity.RealisedGain{}
+
+	for _, investment := range h.investments {
+		profile := h.profiles[investment.AssetId]
+
+		_, realised := helper.MatchFIFOLots(h.ledgers[investment.ID])
+		for _, gain := range realised {
+			if gain.SaleDate.Before(start) || gain.SaleDate.After(end) {
+				continue
+			}
+
+			gain.InvestmentName = investment.Name
+			gain.AssetId = investment.AssetId
+			gain.AssetName = investment.AssetName
+			gain.Term = constant.CapitalGainTermShort
+			if helper.IsLongTerm(gain.PurchaseDate.Time, gain.SaleDate.Time, profile.LTCGHoldingPeriodInMonths) {
+				gain.Term = constant.CapitalGainTermLong
+				if profile.Indexation {
+					gain.Cost, gain.IndexationEstimated = helper.IndexedCost(gain.Cost, gain.PurchaseDate.Time, gain.SaleDate.Time)
+				}
+			}
+
+			gain.Units = helper.RoundToDecimals(gain.Units, 4)
+			gain.Cost = helper.RoundToDecimals(gain.Cost, 2)
+			gain.SaleValue = helper.RoundToDecimals(gain.SaleValue, 2)
+			gain.Gain = helper.RoundToDecimals(gain.SaleValue-gain.Cost, 2)
+			gains = append(gains, gain)
+		}
+	}
+
+	sort.SliceStable(gains, func(i, j int) bool {
+		return gains[i].SaleDate.Before(gains[j].SaleDate.Time)
+	})
+
+	return gains
+}
@@ -0,0 +1,336 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (f FinanceUsecase) GetCashflows(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cashflows, err := f.financeRepo.GetCashflows(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":   "Cashflows fetched successfully",
+			"cashflows": cashflows,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetCashflow(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cashflowId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	cashflow, err := f.financeRepo.GetCashflowById(ctx, userId, cashflowId)
+	if err != nil {
+		return nil, err
+	}
+	if cashflow == nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "cashflow not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":  "Cashflow fetched successfully",
+			"cashflow": cashflow,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) CreateCashflow(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cashflow entity.Cashflow
+	if err := helper.DecodeRequestBody(r, &cashflow); err != nil {
+		return nil, err
+	}
+
+	if err := validateCashflow(&cashflow); err != nil {
+		return nil, err
+	}
+
+	createdCashflow, err := f.financeRepo.CreateCashflow(ctx, userId, cashflow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":  "Cashflow created successfully",
+			"cashflow": createdCashflow,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateCashflow(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cashflowId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var cashflow entity.Cashflow
+	if err := helper.DecodeRequestBody(r, &cashflow); err != nil {
+		return nil, err
+	}
+	cashflow.ID = cashflowId
+
+	if err := validateCashflow(&cashflow); err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.UpdateCashflow(ctx, userId, cashflow)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "cashflow not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":  "Cashflow updated successfully",
+			"cashflow": cashflow,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteCashflow(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cashflowId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.DeleteCashflow(ctx, userId, cashflowId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "cashflow not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Cashflow deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetInvestingSurplusBreakdown(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cashflows, err := f.financeRepo.GetCashflows(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	summaryByCategory := make(map[string]*entity.CashflowCategorySummary)
+	var totalOutflow, surplus float64
+	today := time.Now()
+
+	for _, cashflow := range cashflows {
+		// same monthly view as the investing surplus
+		if !helper.IsCashflowActive(cashflow, today) {
+			continue
+		}
+		amount := helper.MonthlyEquivalentAmount(cashflow)
+		if amount == 0 {
+			continue
+		}
+
+		summary, ok := summaryByCategory[cashflow.Category]
+		if !ok {
+			summary = &entity.CashflowCategorySummary{Category: cashflow.Category}
+			summaryByCategory[cashflow.Category] = summary
+		}
+
+		if cashflow.IsInflow {
+			summary.Inflow += amount
+			surplus += amount
+		} else {
+			summary.Outflow += amount
+			totalOutflow += amount
+			surplus -= amount
+		}
+		summary.Net = summary.Inflow - summary.Outflow
+	}
+
+	// keep the categories in their declared order
+	breakdown := []entity.CashflowCategorySummary{}
+	for _, category := range constant.CashflowCategories {
+		summary, ok := summaryByCategory[category]
+		if !ok {
+			continue
+		}
+		if totalOutflow != 0 {
+			summary.OutflowShareInPercentage = helper.RoundToDecimals(summary.Outflow*100/totalOutflow, 2)
+		}
+		breakdown = append(breakdown, *summary)
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":           "Investing surplus breakdown fetched successfully",
+			"investing-surplus": surplus,
+			"breakdown":         breakdown,
+		},
+		Success: true,
+	}, nil
+}
+
+// GetSurplusProjection projects the monthly surplus of the next years (query param, default 5) from the dated cashflows
+func (f FinanceUsecase) GetSurplusProjection(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	years := constant.DefaultSurplusProjectionYears
+	if value := r.URL.Query().Get("years"); value != "" {
+		years, err = strconv.Atoi(value)
+		if err != nil || years < 1 || years > constant.MaxSurplusProjectionYears {
+			return nil, badRequest(fmt.Sprintf("years must be between 1 and %d", constant.MaxSurplusProjectionYears))
+		}
+	}
+
+	cashflows, err := f.financeRepo.GetCashflows(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	monthly := helper.ProjectMonthlySurplus(cashflows, time.Now(), years*12)
+
+	yearly := []entity.YearlySurplusProjection{}
+	for year := 0; year < years; year++ {
+		summary := entity.YearlySurplusProjection{Year: year + 1}
+		for _, month := range monthly[year*12 : (year+1)*12] {
+			summary.Inflow += month.Inflow
+			summary.Outflow += month.Outflow
+			summary.Surplus += month.Surplus
+		}
+		summary.Inflow = helper.RoundToDecimals(summary.Inflow, 2)
+		summary.Outflow = helper.RoundToDecimals(summary.Outflow, 2)
+		summary.Surplus = helper.RoundToDecimals(summary.Surplus, 2)
+		summary.AverageMonthlySurplus = helper.RoundToDecimals(summary.Surplus/12, 2)
+		yearly = append(yearly, summary)
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Surplus projection fetched successfully",
+			"years":   years,
+			"yearly":  yearly,
+			"monthly": monthly,
+		},
+		Success: true,
+	}, nil
+}
+
+// getInvestingSurplus returns the monthly surplus available for sips. The current mode is today's recurring surplus,
+// the projected mode averages the next 12 months so scheduled hikes, bonuses and ending cashflows are counted
+func (f FinanceUsecase) getInvestingSurplus(ctx context.Context, userId int64, mode string) (float64, error) {
+	if mode != constant.SurplusModeProjected {
+		return f.financeRepo.GetInvestingSurplus(ctx, userId)
+	}
+
+	cashflows, err := f.financeRepo.GetCashflows(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, month := range helper.ProjectMonthlySurplus(cashflows, time.Now(), 12) {
+		total += month.Surplus
+	}
+
+	return helper.RoundToDecimals(total/12, 2), nil
+}
+
+func validateCashflow(cashflow *entity.Cashflow) error {
+	cashflow.Name = strings.TrimSpace(cashflow.Name)
+	cashflow.Category = strings.ToLower(strings.TrimSpace(cashflow.Category))
+
+	if cashflow.Name == "" {
+		return badRequest("name is required")
+	}
+	if cashflow.Amount <= 0 {
+		return badRequest("amount must be greater than 0")
+	}
+	if cashflow.Category == "" {
+		cashflow.Category = constant.CashflowCategoryOther
+	}
+	if !slices.Contains(constant.CashflowCategories, cashflow.Category) {
+		return badRequest("category must be one of " + strings.Join(constant.CashflowCategories, ", "))
+	}
+
+	cashflow.Frequency = strings.ToLower(strings.TrimSpace(cashflow.Frequency))
+	if cashflow.Frequency == "" {
+		cashflow.Frequency = constant.CashflowFrequencyMonthly
+	}
+	if !slices.Contains(constant.CashflowFrequencies, cashflow.Frequency) {
+		return badRequest("frequency must be one of " + strings.Join(constant.CashflowFrequencies, ", "))
+	}
+	if cashflow.Frequency == constant.CashflowFrequencyOneTime && cashflow.StartDate == nil {
+		return badRequest("start_date is required for a one-time cashflow")
+	}
+	if cashflow.StartDate != nil && cashflow.EndDate != nil && cashflow.EndDate.Before(cashflow.StartDate.Time) {
+		return badRequest("end_date cannot be before start_date")
+	}
+	if cashflow.GrowthRateInPercentage < -constant.MaxPercentageAllowed || cashflow.GrowthRateInPercentage > constant.MaxPercentageAllowed {
+		return badRequest("growth_rate_in_percentage must be between -100 and 100")
+	}
+
+	// an edited bank import cashflow is kept as manual, so the next import does not replace it
+	cashflow.Source = constant.CashflowSourceManual
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+)
+
+func (f FinanceUsecase) GetAssetClassCorrelations(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	correlations, err := f.financeRepo.GetAssetClassCorrelations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":      "Asset class correlations fetched successfully",
+			"correlations": correlations,
+		},
+		Success: true,
+	}, nil
+}
+
+// UpdateAssetClassCorrelations replaces the whole correlation matrix, pairs left out are treated as uncorrelated
+func (f FinanceUsecase) UpdateAssetClassCorrelations(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var correlations []entity.AssetClassCorrelation
+	if err := helper.DecodeRequestBody(r, &correlations); err != nil {
+		return nil, err
+	}
+
+	assetClasses, err := f.financeRepo.GetAssetClass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assetClassIds := make(map[int64]bool)
+	for _, assetClass := range assetClasses {
+		assetClassIds[assetClass.ID] = true
+	}
+
+	seen := make(map[[2]int64]bool)
+	for i, correlation := range correlations {
+		if !assetClassIds[correlation.AssetClassIdA] || !assetClassIds[correlation.AssetClassIdB] {
+			return nil, badRequest(fmt.Sprintf("asset class pair (%d, %d) does not exist", correlation.AssetClassIdA, correlation.AssetClassIdB))
+		}
+		if correlation.AssetClassIdA == correlation.AssetClassIdB {
+			return nil, badRequest("an asset class is always fully correlated with itself")
+		}
+		if correlation.Correlation < -1 || correlation.Correlation > 1 {
+			return nil, badRequest("correlation must be between -1 and 1")
+		}
+
+		// every pair is stored once, smaller id first
+		if correlation.AssetClassIdA > correlation.AssetClassIdB {
+			correlations[i].AssetClassIdA, correlations[i].AssetClassIdB = correlation.AssetClassIdB, correlation.AssetClassIdA
+		}
+		pair := [2]int64{correlations[i].AssetClassIdA, correlations[i].AssetClassIdB}
+		if seen[pair] {
+			return nil, badRequest(fmt.Sprintf("asset class pair (%d, %d) is repeated", pair[0], pair[1]))
+		}
+		seen[pair] = true
+	}
+
+	if _, ok := correlationCholesky(assetClasses, correlations); !ok {
+		return nil, badRequest("correlations do not form a valid correlation matrix")
+	}
+
+	if err := f.financeRepo.ReplaceAssetClassCorrelations(ctx, correlations); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":      "Asset class correlations updated successfully",
+			"correlations": correlations,
+		},
+		Success: true,
+	}, nil
+}
+
+// correlationCholesky builds the correlation matrix in the order of assetClasses and decomposes it,
+// ok is false when the matrix is not positive semi-definite
+func correlationCholesky(assetClasses []entity.AssetClass, correlations []entity.AssetClassCorrelation) ([][]float64, bool) {
+	indexById := make(map[int64]int)
+	matrix := make([][]float64, len(assetClasses))
+	for i, assetClass := range assetClasses {
+		indexById[assetClass.ID] = i
+		matrix[i] = make([]float64, len(assetClasses))
+		matrix[i][i] = 1
+	}
+
+	for _, correlation := range correlations {
+		a, okA := indexById[correlation.AssetClassIdA]
+		b, okB := indexById[correlation.AssetClassIdB]
+		if !okA || !okB {
+			continue
+		}
+		matrix[a][b] = correlation.Correlation
+		matrix[b][a] = correlation.Correlation
+	}
+
+	return helper.CholeskyDecomposition(matrix)
+}
@@ -0,0 +1,232 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"time"
+)
+
+// GetEmergencyFund checks how many months of today's expenses the liquid holdings marked as emergency fund cover
+func (f FinanceUsecase) GetEmergencyFund(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := f.getEmergencyFundConfig(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := f.getEmergencyFundStatus(ctx, userId, *config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":        "Emergency fund fetched successfully",
+			"emergency_fund": status,
+		},
+		Success: true,
+	}, nil
+}
+
+// UpdateEmergencyFund saves the target months. With top_up_goal the gap is kept as a goal with the reserved
+// top priority, created or refreshed on every save and removed once there is no gap or the option is turned off
+func (f FinanceUsecase) UpdateEmergencyFund(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var config entity.EmergencyFundConfig
+	if err := helper.DecodeRequestBody(r, &config); err != nil {
+		return nil, err
+	}
+
+	if config.TargetMonths <= 0 || config.TargetMonths > constant.MaxEmergencyFundTargetMonths {
+		return nil, badRequest(fmt.Sprintf("target_months must be greater than 0 and at most %g", constant.MaxEmergencyFundTargetMonths))
+	}
+
+	existing, err := f.getEmergencyFundConfig(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	config.TopUpGoalId = existing.TopUpGoalId
+
+	status, err := f.getEmergencyFundStatus(ctx, userId, config)
+	if err != nil {
+		return nil, err
+	}
+
+	config.TopUpGoalId, err = f.syncEmergencyFundGoal(ctx, userId, config, status.Gap)
+	if err != nil {
+		return nil, err
+	}
+	status.TopUpGoalId = config.TopUpGoalId
+
+	if err := f.financeRepo.SaveEmergencyFundConfig(ctx, userId, config); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":        "Emergency fund updated successfully",
+			"emergency_fund": status,
+		},
+		Success: true,
+	}, nil
+}
+
+// withEmergencyFundGoal swaps the stored top-up goal for one sized to today's gap, without saving it.
+// Expenses and holdings change and the yearly roll forward ages the stored goal, so the sip allocator funds this one
+func (f FinanceUsecase) withEmergencyFundGoal(ctx context.Context, userId int64, goals []entity.Goals) ([]entity.Goals, error) {
+	config, err := f.getEmergencyFundConfig(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if !config.TopUpGoal {
+		return goals, nil
+	}
+
+	status, err := f.getEmergencyFundStatus(ctx, userId, *config)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored *entity.Goals
+	others := []entity.Goals{}
+	for i, goal := range goals {
+		if config.TopUpGoalId != nil && goal.ID == *config.TopUpGoalId {
+			stored = &goals[i]
+			continue
+		}
+		others = append(others, goal)
+	}
+
+	if status.Gap <= 0 {
+		return others, nil
+	}
+	return append(others, emergencyFundTopUpGoal(*config, status.Gap, stored)), nil
+}
+
+// getEmergencyFundConfig falls back to the default target when the user has not configured one
+func (f FinanceUsecase) getEmergencyFundConfig(ctx context.Context, userId int64) (*entity.EmergencyFundConfig, error) {
+	config, err := f.financeRepo.GetEmergencyFundConfig(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = &entity.EmergencyFundConfig{TargetMonths: constant.DefaultEmergencyFundTargetMonths}
+	}
+	return config, nil
+}
+
+func (f FinanceUsecase) getEmergencyFundStatus(ctx context.Context, userId int64, config entity.EmergencyFundConfig) (*entity.EmergencyFundStatus, error) {
+	cashflows, err := f.financeRepo.GetCashflows(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	investments, err := f.financeRepo.GetInvestments(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the same monthly view of the expenses as the investing surplus
+	today := time.Now()
+	var monthlyExpenses float64
+	for _, cashflow := range cashflows {
+		if !cashflow.IsInflow && helper.IsCashflowActive(cashflow, today) {
+			monthlyExpenses += helper.MonthlyEquivalentAmount(cashflow)
+		}
+	}
+
+	var emergencyFund float64
+	for _, investment := range investments {
+		if investment.IsEmergencyFund && investment.Type == constant.LiquidityTypeLiquid {
+			emergencyFund += investment.Amount
+		}
+	}
+
+	status := &entity.EmergencyFundStatus{
+		MonthlyExpenses: helper.RoundToDecimals(monthlyExpenses, 2),
+		EmergencyFund:   helper.RoundToDecimals(emergencyFund, 2),
+		TargetMonths:    config.TargetMonths,
+		TargetAmount:    helper.RoundToDecimals(monthlyExpenses*config.TargetMonths, 2),
+		TopUpGoalId:     config.TopUpGoalId,
+	}
+	if monthlyExpenses > 0 {
+		monthsCovered := helper.RoundToDecimals(emergencyFund/monthlyExpenses, 1)
+		status.MonthsCovered = &monthsCovered
+	}
+	if status.TargetAmount > status.EmergencyFund {
+		status.Gap = helper.RoundToDecimals(status.TargetAmount-status.EmergencyFund, 2)
+	}
+	status.IsAdequate = status.Gap == 0
+
+	return status, nil
+}
+
+// syncEmergencyFundGoal creates, refreshes or removes the top-up goal and returns its id, nil when there is none
+func (f FinanceUsecase) syncEmergencyFundGoal(ctx context.Context, userId int64, config entity.EmergencyFundConfig, gap float64) (*int64, error) {
+	var existing *entity.Goals
+	if config.TopUpGoalId != nil {
+		goal, err := f.financeRepo.GetGoalById(ctx, userId, *config.TopUpGoalId)
+		if err != nil {
+			return nil, err
+		}
+		existing = goal
+	}
+
+	if !config.TopUpGoal || gap <= 0 {
+		if existing != nil {
+			if _, err := f.financeRepo.DeleteGoal(ctx, userId, existing.ID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	goal := emergencyFundTopUpGoal(config, gap, existing)
+	if existing == nil {
+		created, err := f.financeRepo.CreateGoal(ctx, userId, goal)
+		if err != nil {
+			return nil, err
+		}
+		return &created.ID, nil
+	}
+
+	if _, err := f.financeRepo.UpdateGoal(ctx, userId, goal); err != nil {
+		return nil, err
+	}
+
+	return &existing.ID, nil
+}
+
+// emergencyFundTopUpGoal sizes the top-up goal to the gap. The gap is needed now, so it is planned over the shortest
+// horizon without inflation. Edits to the step up or the current sip of the existing goal are kept
+func emergencyFundTopUpGoal(config entity.EmergencyFundConfig, gap float64, existing *entity.Goals) entity.Goals {
+	goal := entity.Goals{
+		Name:        constant.EmergencyFundTopUpGoalName,
+		Description: fmt.Sprintf("Fills the emergency fund up to %g months of expenses", config.TargetMonths),
+	}
+	if existing != nil {
+		goal = *existing
+	}
+
+	goal.TodayAmount = gap
+	goal.YearsLeft = constant.EmergencyFundTopUpYears
+	goal.InflationPercentage = 0
+	goal.Priority = constant.EmergencyFundGoalPriority
+	goal.IsDue = false
+
+	return goal
+}
@@ -0,0 +1,98 @@
+package finance
+
+import (
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"math"
+	"sort"
+	"time"
+)
+
+// fundGoals shares the surplus between the goals as per the policy and fills the funded and shortfall sip.
+// With the priority policy goals of the same priority share what is left pro-rata.
+// The emergency fund top-up goal is funded first under every policy
+func fundGoals(fundings []entity.GoalFunding, surplus float64, policy string) {
+	remaining := math.Max(surplus, 0)
+
+	sort.SliceStable(fundings, func(i, j int) bool {
+		return fundings[i].Priority == constant.EmergencyFundGoalPriority && fundings[j].Priority != constant.EmergencyFundGoalPriority
+	})
+	reserved := 0
+	for reserved < len(fundings) && fundings[reserved].Priority == constant.EmergencyFundGoalPriority {
+		reserved++
+	}
+	remaining -= fundProRata(fundings[:reserved], remaining)
+	others := fundings[reserved:]
+
+	if policy == constant.SIPFundingPolicyProRata {
+		fundProRata(others, remaining)
+	} else {
+		sort.SliceStable(others, func(i, j int) bool {
+			return others[i].Priority < others[j].Priority
+		})
+
+		for start := 0; start < len(others); {
+			end := start
+			for end < len(others) && others[end].Priority == others[start].Priority {
+				end++
+			}
+			remaining -= fundProRata(others[start:end], remaining)
+			start = end
+		}
+	}
+
+	for i := range fundings {
+		fundings[i].ShortfallSIP = helper.RoundToDecimals(fundings[i].RequiredSIP-fundings[i].FundedSIP, 2)
+	}
+}
+
+// fundProRata funds every goal by the same share of its required sip and returns the amount used
+func fundProRata(fundings []entity.GoalFunding, available float64) float64 {
+	var totalRequired float64
+	for _, funding := range fundings {
+		totalRequired += funding.RequiredSIP
+	}
+	if totalRequired == 0 {
+		return 0
+	}
+
+	share := math.Min(1, available/totalRequired)
+	var used float64
+	for i := range fundings {
+		fundings[i].FundedSIP = helper.RoundToDecimals(fundings[i].RequiredSIP*share, 2)
+		used += fundings[i].FundedSIP
+	}
+
+	return used
+}
+
+// projectFundedGoal fills the corpus the funded sip reaches by the due date, and for an underfunded goal the
+// first year in which the sip, kept going after the due date, catches up with the still inflating target.
+// The search stops MaxGoalCatchUpYears after the due date and the goal is then reported as not reachable
+func projectFundedGoal(funding *entity.GoalFunding, goal entity.Goals, glidePath *entity.GoalGlidePath) {
+	// after the due date the corpus stays in the last band of the glide path
+	yearlyReturns := make([]float64, goal.YearsLeft+constant.MaxGoalCatchUpYears)
+	for year := range yearlyReturns {
+		yearlyReturns[year] = glidePath.Path[min(year, len(glidePath.Path)-1)].ReturnInPercentage
+	}
+
+	yearEndCorpus := helper.ProjectGlidePathCorpus(funding.FundedSIP, yearlyReturns, goal.SIPStepUpPercentage)
+	funding.AchievableCorpus = helper.RoundToDecimals(yearEndCorpus[goal.YearsLeft-1]+goal.AllocatedAmount, 2)
+
+	if funding.ShortfallSIP <= 0 {
+		return
+	}
+
+	for year := goal.YearsLeft; year < int64(len(yearEndCorpus)); year++ {
+		target := helper.InflationCalculator(goal.TodayAmount, year+1, goal.InflationPercentage)
+		if yearEndCorpus[year]+goal.AllocatedAmount >= target {
+			completionYear := int64(time.Now().Year()) + year + 1
+			funding.DelayedCompletionYear = &completionYear
+			return
+		}
+	}
+
+	catchUpYears := int64(constant.MaxGoalCatchUpYears)
+	funding.NotReachableWithinYears = &catchUpYears
+}
@@ -0,0 +1,140 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+)
+
+// glidePathBand is an allocation type with its effective return and asset split
+type glidePathBand struct {
+	allocationType entity.AllocationType
+	returns        float64
+	config         []entity.AllocationTypeConfig
+}
+
+func (f FinanceUsecase) GetGlidePath(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goalsData, err := f.financeRepo.GetGoals(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	bands, err := f.getGlidePathBands(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	glidePaths := []entity.GoalGlidePath{}
+	for _, goal := range goalsData {
+		glidePath, err := projectGoalGlidePath(goal, bands)
+		if err != nil {
+			return nil, err
+		}
+		glidePaths = append(glidePaths, *glidePath)
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":     "Glide path fetched successfully",
+			"glide_paths": glidePaths,
+		},
+		Success: true,
+	}, nil
+}
+
+// getGlidePathBands loads every allocation type once, so a goal can be projected without a query per year
+func (f FinanceUsecase) getGlidePathBands(ctx context.Context) ([]glidePathBand, error) {
+	allocationTypes, err := f.financeRepo.GetAllocationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allocationTypeReturnsMap, err := f.getAllocationTypeReturns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var bands []glidePathBand
+	for _, allocationType := range allocationTypes {
+		config, err := f.financeRepo.GetAllocationConfigByAllocationTypeId(ctx, allocationType.ID)
+		if err != nil {
+			return nil, err
+		}
+		bands = append(bands, glidePathBand{
+			allocationType: allocationType,
+			returns:        allocationTypeReturnsMap[allocationType.Name],
+			config:         config,
+		})
+	}
+
+	return bands, nil
+}
+
+// bandForYearsLeft matches the same way GetAllocationByYearLeft does
+func bandForYearsLeft(bands []glidePathBand, yearsLeft int64) (*glidePathBand, error) {
+	for i, band := range bands {
+		if yearsLeft >= band.allocationType.MinAge && (band.allocationType.MaxAge == nil || yearsLeft <= *band.allocationType.MaxAge) {
+			return &bands[i], nil
+		}
+	}
+	return nil, &entity.CustomError{StatusCode: http.StatusUnprocessableEntity, Message: fmt.Sprintf("no allocation type is configured for %d years left", yearsLeft)}
+}
+
+// projectGoalGlidePath walks the goal year by year, each year invested as per the band of its years left,
+// and solves the sip that reaches the required amount with those returns
+func projectGoalGlidePath(goal entity.Goals, bands []glidePathBand) (*entity.GoalGlidePath, error) {
+	targetAmount := helper.InflationCalculator(goal.TodayAmount, goal.YearsLeft, goal.InflationPercentage)
+	requiredAmount := targetAmount - goal.AllocatedAmount
+
+	glidePath := &entity.GoalGlidePath{
+		GoalId:         goal.ID,
+		GoalName:       goal.Name,
+		YearsLeft:      goal.YearsLeft,
+		TargetAmount:   targetAmount,
+		RequiredAmount: requiredAmount,
+		Path:           []entity.GlidePathYear{},
+	}
+
+	yearBands := make([]*glidePathBand, goal.YearsLeft)
+	yearlyReturns := make([]float64, goal.YearsLeft)
+	for year := int64(0); year < goal.YearsLeft; year++ {
+		band, err := bandForYearsLeft(bands, goal.YearsLeft-year)
+		if err != nil {
+			return nil, err
+		}
+		yearBands[year] = band
+		yearlyReturns[year] = band.returns
+	}
+
+	// due or already funded goals need no sip, the path still shows how the corpus is held
+	if requiredAmount > 0 && goal.YearsLeft > 0 {
+		glidePath.GlidePathSIP = helper.CalculateGlidePathSIP(requiredAmount, yearlyReturns, goal.SIPStepUpPercentage)
+		glidePath.FlatSIP = helper.CalculateSIPRequired(requiredAmount, goal.YearsLeft, yearlyReturns[0], goal.SIPStepUpPercentage)
+	}
+
+	yearEndCorpus := helper.ProjectGlidePathCorpus(glidePath.GlidePathSIP, yearlyReturns, goal.SIPStepUpPercentage)
+	stepUp := 1.0
+	for year, band := range yearBands {
+		glidePath.Path = append(glidePath.Path, entity.GlidePathYear{
+			Year:               int64(year) + 1,
+			YearsLeft:          goal.YearsLeft - int64(year),
+			AllocationTypeId:   band.allocationType.ID,
+			AllocationTypeName: band.allocationType.Name,
+			ReturnInPercentage: band.returns,
+			MonthlySIP:         helper.RoundToDecimals(glidePath.GlidePathSIP*stepUp, 2),
+			ProjectedCorpus:    yearEndCorpus[year],
+			AssetSplit:         band.config,
+		})
+		stepUp *= 1 + goal.SIPStepUpPercentage/100
+	}
+
+	return glidePath, nil
+}
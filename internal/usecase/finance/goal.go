@@ -0,0 +1,186 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"strings"
+)
+
+func (f FinanceUsecase) CreateGoal(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var goal entity.Goals
+	if err := helper.DecodeRequestBody(r, &goal); err != nil {
+		return nil, err
+	}
+
+	if err := validateGoal(&goal); err != nil {
+		return nil, err
+	}
+
+	createdGoal, err := f.financeRepo.CreateGoal(ctx, userId, goal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Goal created successfully",
+			"goal":    createdGoal,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetGoal(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goalId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := f.financeRepo.GetGoalById(ctx, userId, goalId)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "goal not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Goal fetched successfully",
+			"goal":    goal,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateGoal(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goalId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var goal entity.Goals
+	if err := helper.DecodeRequestBody(r, &goal); err != nil {
+		return nil, err
+	}
+	goal.ID = goalId
+
+	if err := validateGoal(&goal); err != nil {
+		return nil, err
+	}
+
+	// the emergency fund top-up goal keeps its reserved priority
+	existing, err := f.financeRepo.GetGoalById(ctx, userId, goalId)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Priority == constant.EmergencyFundGoalPriority {
+		goal.Priority = constant.EmergencyFundGoalPriority
+	}
+
+	found, err := f.financeRepo.UpdateGoal(ctx, userId, goal)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "goal not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Goal updated successfully",
+			"goal":    goal,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteGoal(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goalId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.DeleteGoal(ctx, userId, goalId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "goal not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Goal deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+func validateGoal(goal *entity.Goals) error {
+	goal.Name = strings.TrimSpace(goal.Name)
+
+	if goal.Name == "" {
+		return badRequest("name is required")
+	}
+	if goal.YearsLeft < 0 || goal.YearsLeft > constant.MaxGoalYearsLeft {
+		return badRequest(fmt.Sprintf("years_left must be between 0 and %d", constant.MaxGoalYearsLeft))
+	}
+	if goal.InflationPercentage < 0 || goal.InflationPercentage > constant.MaxPercentageAllowed {
+		return badRequest("inflation_percentage must be between 0 and 100")
+	}
+	if goal.TodayAmount <= 0 {
+		return badRequest("today_amount must be greater than 0")
+	}
+	if goal.AllocatedAmount < 0 {
+		return badRequest("allocated_amount cannot be negative")
+	}
+	if goal.SIPStepUpPercentage < 0 || goal.SIPStepUpPercentage > constant.MaxPercentageAllowed {
+		return badRequest("sip_step_up_percentage must be between 0 and 100")
+	}
+	if goal.CurrentSIP < 0 {
+		return badRequest("current_sip cannot be negative")
+	}
+	if goal.Priority == 0 {
+		goal.Priority = constant.DefaultGoalPriority
+	}
+	if goal.Priority < 1 {
+		return badRequest("priority must be 1 or more")
+	}
+
+	goal.IsDue = goal.YearsLeft == 0
+
+	return nil
+}
+
+func badRequest(message string) error {
+	return &entity.CustomError{StatusCode: http.StatusBadRequest, Message: message}
+}
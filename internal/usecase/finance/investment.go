@@ -0,0 +1,222 @@
+package finance
+
+import (
+	"context"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+func (f FinanceUsecase) GetInvestments(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	investments, err := f.financeRepo.GetInvestments(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":     "Investments fetched successfully",
+			"investments": investments,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetInvestment(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	investmentId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	investment, err := f.financeRepo.GetInvestmentById(ctx, userId, investmentId)
+	if err != nil {
+		return nil, err
+	}
+	if investment == nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "investment not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":    "Investment fetched successfully",
+			"investment": investment,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) CreateInvestment(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var investment entity.Investment
+	if err := helper.DecodeRequestBody(r, &investment); err != nil {
+		return nil, err
+	}
+
+	if err := f.validateInvestment(ctx, &investment); err != nil {
+		return nil, err
+	}
+
+	createdInvestment, err := f.financeRepo.CreateInvestment(ctx, userId, investment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":    "Investment created successfully",
+			"investment": createdInvestment,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateInvestment(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	investmentId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var investment entity.Investment
+	if err := helper.DecodeRequestBody(r, &investment); err != nil {
+		return nil, err
+	}
+	investment.ID = investmentId
+
+	if err := f.validateInvestment(ctx, &investment); err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.UpdateInvestment(ctx, userId, investment)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "investment not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":    "Investment updated successfully",
+			"investment": investment,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteInvestment(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	investmentId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.DeleteInvestment(ctx, userId, investmentId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "investment not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Investment deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+// validateInvestment normalizes the liquidity type and checks the asset class and sub category exist and match
+func (f FinanceUsecase) validateInvestment(ctx context.Context, investment *entity.Investment) error {
+	investment.Name = strings.TrimSpace(investment.Name)
+	investment.Type = strings.ToLower(strings.TrimSpace(investment.Type))
+
+	if investment.Name == "" {
+		return badRequest("name is required")
+	}
+	if investment.Amount < 0 {
+		return badRequest("amount cannot be negative")
+	}
+	if investment.Type != constant.LiquidityTypeLiquid && investment.Type != constant.LiquidityTypeIlliquid {
+		return badRequest("type must be either liquid or illiquid")
+	}
+	if investment.IsEmergencyFund && investment.Type != constant.LiquidityTypeLiquid {
+		return badRequest("only liquid investments can be part of the emergency fund")
+	}
+
+	if investment.TaxSection != nil {
+		section := strings.ToUpper(strings.TrimSpace(*investment.TaxSection))
+		if !slices.Contains(constant.TaxSections, section) {
+			return badRequest("tax_section must be one of " + strings.Join(constant.TaxSections, ", "))
+		}
+		investment.TaxSection = &section
+	}
+
+	assetClasses, err := f.financeRepo.GetAssetClass(ctx)
+	if err != nil {
+		return err
+	}
+
+	investment.AssetName = ""
+	for _, assetClass := range assetClasses {
+		if assetClass.ID == investment.AssetId {
+			investment.AssetName = assetClass.Name
+			break
+		}
+	}
+	if investment.AssetName == "" {
+		return badRequest("asset_id does not exist")
+	}
+
+	if err := f.valueUnitHolding(ctx, investment); err != nil {
+		return err
+	}
+
+	if investment.AssetSubCategoryId == nil {
+		return nil
+	}
+
+	subCategory, err := f.financeRepo.GetAssetSubCategoryById(ctx, *investment.AssetSubCategoryId)
+	if err != nil {
+		return err
+	}
+	if subCategory == nil {
+		return badRequest("asset_sub_category_id does not exist")
+	}
+	if subCategory.AssetClassId != investment.AssetId {
+		return badRequest("asset_sub_category_id does not belong to the asset class")
+	}
+
+	return nil
+}
@@ -0,0 +1,367 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+func (f FinanceUsecase) GetInvestmentTransactions(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	investment, err := f.getInvestmentFromUrlParam(ctx, r, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := f.financeRepo.GetInvestmentTransactions(ctx, userId, investment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":      "Investment transactions fetched successfully",
+			"transactions": transactions,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) CreateInvestmentTransaction(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	investment, err := f.getInvestmentFromUrlParam(ctx, r, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var transaction entity.InvestmentTransaction
+	if err := helper.DecodeRequestBody(r, &transaction); err != nil {
+		return nil, err
+	}
+	transaction.InvestmentId = investment.ID
+
+	if err := validateInvestmentTransaction(&transaction); err != nil {
+		return nil, err
+	}
+
+	// a sell cannot take more units than held on its date
+	transactions, err := f.financeRepo.GetInvestmentTransactions(ctx, userId, investment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLedgerUnits(append(transactions, transaction)); err != nil {
+		return nil, badRequest(err.Error())
+	}
+
+	createdTransaction, err := f.financeRepo.CreateInvestmentTransaction(ctx, userId, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":     "Investment transaction created successfully",
+			"transaction": createdTransaction,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteInvestmentTransaction(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	investment, err := f.getInvestmentFromUrlParam(ctx, r, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionId, err := helper.GetIdFromUrlParam(r, "transactionId")
+	if err != nil {
+		return nil, err
+	}
+
+	// removing a purchase must not leave a later sell without units
+	transactions, err := f.financeRepo.GetInvestmentTransactions(ctx, userId, investment.ID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := slices.DeleteFunc(transactions, func(transaction entity.InvestmentTransaction) bool {
+		return transaction.ID == transactionId
+	})
+	if err := validateLedgerUnits(remaining); err != nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusConflict, Message: err.Error()}
+	}
+
+	found, err := f.financeRepo.DeleteInvestmentTransaction(ctx, userId, investment.ID, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "investment transaction not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Investment transaction deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+// GetInvestmentPerformance reports cost basis, gains, CAGR and XIRR per holding, per asset class and for the portfolio.
+// Holdings without a ledger are listed separately, their whole value would otherwise count as gain
+func (f FinanceUsecase) GetInvestmentPerformance(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	investments, err := f.financeRepo.GetInvestments(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := f.financeRepo.GetInvestmentTransactions(ctx, userId, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionsByInvestment := make(map[int64][]entity.InvestmentTransaction)
+	for _, transaction := range transactions {
+		transactionsByInvestment[transaction.InvestmentId] = append(transactionsByInvestment[transaction.InvestmentId], transaction)
+	}
+
+	now := time.Now()
+	holdings := []entity.HoldingPerformance{}
+	holdingsWithoutTransactions := []int64{}
+	assetClasses := []entity.AssetClassPerformance{}
+	assetClassIndexById := make(map[int64]int)
+	assetClassTransactions := make(map[int64][]entity.InvestmentTransaction)
+	assetClassMarketValue := make(map[int64]float64)
+	var portfolioMarketValue float64
+
+	for _, investment := range investments {
+		ledger := transactionsByInvestment[investment.ID]
+		if len(ledger) == 0 {
+			holdingsWithoutTransactions = append(holdingsWithoutTransactions, investment.ID)
+			continue
+		}
+
+		holding := entity.HoldingPerformance{
+			InvestmentId:          investment.ID,
+			InvestmentName:        investment.Name,
+			AssetId:               investment.AssetId,
+			AssetName:             investment.AssetName,
+			Units:                 helper.RoundToDecimals(heldUnits(ledger), 4),
+			InvestmentPerformance: investmentPerformance(ledger, investment.Amount, now),
+		}
+		holding.FIFO = helper.FIFOCostBasis(ledger, investment.Amount)
+		holding.AverageCost = helper.AverageCostBasis(ledger, investment.Amount)
+		holdings = append(holdings, holding)
+
+		if _, ok := assetClassIndexById[investment.AssetId]; !ok {
+			assetClassIndexById[investment.AssetId] = len(assetClasses)
+			assetClasses = append(assetClasses, entity.AssetClassPerformance{AssetId: investment.AssetId, AssetName: investment.AssetName})
+		}
+		assetClassTransactions[investment.AssetId] = append(assetClassTransactions[investment.AssetId], ledger...)
+		assetClassMarketValue[investment.AssetId] += investment.Amount
+		portfolioMarketValue += investment.Amount
+	}
+
+	// cost basis is matched per holding, the aggregates add those up
+	for i := range assetClasses {
+		assetId := assetClasses[i].AssetId
+		assetClasses[i].InvestmentPerformance = investmentPerformance(assetClassTransactions[assetId], assetClassMarketValue[assetId], now)
+		for _, holding := range holdings {
+			if holding.AssetId == assetId {
+				assetClasses[i].FIFO = addCostBasis(assetClasses[i].FIFO, holding.FIFO)
+				assetClasses[i].AverageCost = addCostBasis(assetClasses[i].AverageCost, holding.AverageCost)
+			}
+		}
+	}
+	portfolio := investmentPerformance(transactions, portfolioMarketValue, now)
+	for _, assetClass := range assetClasses {
+		portfolio.FIFO = addCostBasis(portfolio.FIFO, assetClass.FIFO)
+		portfolio.AverageCost = addCostBasis(portfolio.AverageCost, assetClass.AverageCost)
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":                       "Investment performance fetched successfully",
+			"holdings":                      holdings,
+			"asset_classes":                 assetClasses,
+			"portfolio":                     portfolio,
+			"holdings_without_transactions": holdingsWithoutTransactions,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) getInvestmentFromUrlParam(ctx context.Context, r *http.Request, userId int64) (*entity.Investment, error) {
+	investmentId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	investment, err := f.financeRepo.GetInvestmentById(ctx, userId, investmentId)
+	if err != nil {
+		return nil, err
+	}
+	if investment == nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "investment not found"}
+	}
+
+	return investment, nil
+}
+
+// investmentPerformance fills everything but the cost basis, which is only meaningful per holding.
+// transactions must be ordered by date
+func investmentPerformance(transactions []entity.InvestmentTransaction, marketValue float64, now time.Time) entity.InvestmentPerformance {
+	performance := entity.InvestmentPerformance{MarketValue: helper.RoundToDecimals(marketValue, 2)}
+
+	cashflows := []entity.DatedCashflow{}
+	firstDate := now
+	for _, transaction := range transactions {
+		if transaction.TransactionDate.Before(firstDate) {
+			firstDate = transaction.TransactionDate.Time
+		}
+
+		switch transaction.Type {
+		case constant.TransactionTypeBuy, constant.TransactionTypeSIP:
+			performance.TotalInvested += transaction.Amount
+			cashflows = append(cashflows, entity.DatedCashflow{Date: transaction.TransactionDate.Time, Amount: -transaction.Amount})
+		case constant.TransactionTypeSell:
+			performance.TotalWithdrawn += transaction.Amount
+			cashflows = append(cashflows, entity.DatedCashflow{Date: transaction.TransactionDate.Time, Amount: transaction.Amount})
+		case constant.TransactionTypeDividend:
+			performance.Dividends += transaction.Amount
+			cashflows = append(cashflows, entity.DatedCashflow{Date: transaction.TransactionDate.Time, Amount: transaction.Amount})
+		}
+	}
+	// the current value counts as if the holding was sold today
+	cashflows = append(cashflows, entity.DatedCashflow{Date: now, Amount: marketValue})
+
+	endValue := marketValue + performance.TotalWithdrawn + performance.Dividends
+	performance.AbsoluteGain = helper.RoundToDecimals(endValue-performance.TotalInvested, 2)
+
+	// annualising a holding younger than a year blows short term moves up into huge (or infinite) rates,
+	// so those only report the absolute gain
+	years := now.Sub(firstDate).Hours() / 24 / 365
+	if performance.TotalInvested > 0 && years >= 1 {
+		cagr := (math.Pow(endValue/performance.TotalInvested, 1/years) - 1) * 100
+		if isFinite(cagr) {
+			cagr = helper.RoundToDecimals(cagr, 2)
+			performance.CAGRInPercentage = &cagr
+		}
+	}
+
+	if xirr, ok := helper.XIRR(cashflows); ok && isFinite(xirr) {
+		xirr = helper.RoundToDecimals(xirr, 2)
+		performance.XIRRInPercentage = &xirr
+	}
+
+	performance.TotalInvested = helper.RoundToDecimals(performance.TotalInvested, 2)
+	performance.TotalWithdrawn = helper.RoundToDecimals(performance.TotalWithdrawn, 2)
+	performance.Dividends = helper.RoundToDecimals(performance.Dividends, 2)
+
+	return performance
+}
+
+// isFinite guards rates before they reach the response, json cannot encode NaN or Inf
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func addCostBasis(a entity.CostBasis, b entity.CostBasis) entity.CostBasis {
+	return entity.CostBasis{
+		RemainingCost:  helper.RoundToDecimals(a.RemainingCost+b.RemainingCost, 2),
+		RealizedGain:   helper.RoundToDecimals(a.RealizedGain+b.RealizedGain, 2),
+		UnrealizedGain: helper.RoundToDecimals(a.UnrealizedGain+b.UnrealizedGain, 2),
+	}
+}
+
+func heldUnits(transactions []entity.InvestmentTransaction) float64 {
+	var units float64
+	for _, transaction := range transactions {
+		switch transaction.Type {
+		case constant.TransactionTypeBuy, constant.TransactionTypeSIP:
+			units += transaction.Units
+		case constant.TransactionTypeSell:
+			units -= transaction.Units
+		}
+	}
+	return units
+}
+
+// validateLedgerUnits walks the ledger in date order and fails when a sell takes more units than held
+func validateLedgerUnits(transactions []entity.InvestmentTransaction) error {
+	ordered := make([]entity.InvestmentTransaction, len(transactions))
+	copy(ordered, transactions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].TransactionDate.Before(ordered[j].TransactionDate.Time)
+	})
+
+	var units float64
+	for _, transaction := range ordered {
+		switch transaction.Type {
+		case constant.TransactionTypeBuy, constant.TransactionTypeSIP:
+			units += transaction.Units
+		case constant.TransactionTypeSell:
+			units -= transaction.Units
+			// small tolerance for units recorded with a few decimals
+			if units < -1e-6 {
+				return fmt.Errorf("sell on %s takes more units than held", transaction.TransactionDate.Format(entity.DateLayout))
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateInvestmentTransaction(transaction *entity.InvestmentTransaction) error {
+	transaction.Type = strings.ToLower(strings.TrimSpace(transaction.Type))
+
+	if !slices.Contains(constant.TransactionTypes, transaction.Type) {
+		return badRequest(fmt.Sprintf("type must be one of %s", strings.Join(constant.TransactionTypes, ", ")))
+	}
+	if transaction.TransactionDate.IsZero() {
+		return badRequest("transaction_date is required")
+	}
+	if transaction.TransactionDate.After(time.Now()) {
+		return badRequest("transaction_date cannot be in the future")
+	}
+	if transaction.Amount <= 0 {
+		return badRequest("amount must be greater than 0")
+	}
+
+	if transaction.Type == constant.TransactionTypeDividend {
+		transaction.Units = 0
+	} else if transaction.Units <= 0 {
+		return badRequest("units must be greater than 0")
+	}
+
+	return nil
+}
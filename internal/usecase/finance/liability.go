@@ -0,0 +1,312 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (f FinanceUsecase) GetLiabilities(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	liabilities, err := f.financeRepo.GetLiabilities(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalOutstanding float64
+	for i := range liabilities {
+		liabilities[i].OutstandingPrincipal = outstandingPrincipal(liabilities[i], time.Now())
+		totalOutstanding += liabilities[i].OutstandingPrincipal
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":           "Liabilities fetched successfully",
+			"liabilities":       liabilities,
+			"total_outstanding": helper.RoundToDecimals(totalOutstanding, 2),
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetLiability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	liability, err := f.getLiabilityFromUrl(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	liability.OutstandingPrincipal = outstandingPrincipal(*liability, time.Now())
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":   "Liability fetched successfully",
+			"liability": liability,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) CreateLiability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var liability entity.Liability
+	if err := helper.DecodeRequestBody(r, &liability); err != nil {
+		return nil, err
+	}
+
+	if err := validateLiability(&liability); err != nil {
+		return nil, err
+	}
+
+	createdLiability, err := f.financeRepo.CreateLiability(ctx, userId, liability)
+	if err != nil {
+		return nil, err
+	}
+	createdLiability.OutstandingPrincipal = outstandingPrincipal(*createdLiability, time.Now())
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":   "Liability created successfully",
+			"liability": createdLiability,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateLiability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	liabilityId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var liability entity.Liability
+	if err := helper.DecodeRequestBody(r, &liability); err != nil {
+		return nil, err
+	}
+	liability.ID = liabilityId
+
+	if err := validateLiability(&liability); err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.UpdateLiability(ctx, userId, liability)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "liability not found"}
+	}
+	liability.OutstandingPrincipal = outstandingPrincipal(liability, time.Now())
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":   "Liability updated successfully",
+			"liability": liability,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteLiability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	liabilityId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.DeleteLiability(ctx, userId, liabilityId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "liability not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Liability deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetAmortizationSchedule(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	liability, err := f.getLiabilityFromUrl(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if liability.TenureInMonths == 0 {
+		return nil, badRequest("liability has no emi plan to amortize")
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":      "Amortization schedule fetched successfully",
+			"amortization": amortizationSchedule(*liability, time.Now()),
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetAmortizationSchedules(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	liabilities, err := f.financeRepo.GetLiabilities(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []entity.AmortizationSchedule{}
+	for _, liability := range liabilities {
+		if liability.TenureInMonths == 0 {
+			continue
+		}
+		schedules = append(schedules, amortizationSchedule(liability, time.Now()))
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":      "Amortization schedules fetched successfully",
+			"amortization": schedules,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) getLiabilityFromUrl(ctx context.Context, r *http.Request) (*entity.Liability, error) {
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	liabilityId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	liability, err := f.financeRepo.GetLiabilityById(ctx, userId, liabilityId)
+	if err != nil {
+		return nil, err
+	}
+	if liability == nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "liability not found"}
+	}
+
+	return liability, nil
+}
+
+// getOutstandingLiabilities sums the outstanding principal of every liability as of today
+func (f FinanceUsecase) getOutstandingLiabilities(ctx context.Context, userId int64) (float64, error) {
+	liabilities, err := f.financeRepo.GetLiabilities(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, liability := range liabilities {
+		total += outstandingPrincipal(liability, time.Now())
+	}
+
+	return helper.RoundToDecimals(total, 2), nil
+}
+
+func amortizationSchedule(liability entity.Liability, asOf time.Time) entity.AmortizationSchedule {
+	schedule := helper.AmortizationSchedule(
+		liability.Amount,
+		liability.InterestRateInPercentage,
+		liability.TenureInMonths,
+		liability.EMI,
+		liability.StartDate.Time,
+	)
+
+	var totalInterest float64
+	for _, entry := range schedule {
+		totalInterest += entry.Interest
+	}
+
+	return entity.AmortizationSchedule{
+		LiabilityId:          liability.ID,
+		LiabilityName:        liability.Name,
+		EMI:                  liability.EMI,
+		TotalInterest:        helper.RoundToDecimals(totalInterest, 2),
+		OutstandingPrincipal: helper.OutstandingPrincipal(liability.Amount, schedule, asOf),
+		Schedule:             schedule,
+	}
+}
+
+// outstandingPrincipal is the full amount for liabilities without an emi plan
+func outstandingPrincipal(liability entity.Liability, asOf time.Time) float64 {
+	if liability.TenureInMonths == 0 || liability.StartDate == nil {
+		return liability.Amount
+	}
+	return amortizationSchedule(liability, asOf).OutstandingPrincipal
+}
+
+func validateLiability(liability *entity.Liability) error {
+	liability.Name = strings.TrimSpace(liability.Name)
+
+	if liability.Name == "" {
+		return badRequest("name is required")
+	}
+	if liability.Amount <= 0 {
+		return badRequest("amount must be greater than 0")
+	}
+	if liability.InterestRateInPercentage < 0 || liability.InterestRateInPercentage > constant.MaxPercentageAllowed {
+		return badRequest("interest_rate_in_percentage must be between 0 and 100")
+	}
+	if liability.TenureInMonths < 0 || liability.TenureInMonths > constant.MaxLoanTenureInMonths {
+		return badRequest(fmt.Sprintf("tenure_in_months must be between 0 and %d", constant.MaxLoanTenureInMonths))
+	}
+	if liability.EMI < 0 {
+		return badRequest("emi cannot be negative")
+	}
+
+	if liability.TenureInMonths == 0 {
+		return nil
+	}
+
+	// loans need a start date and an emi that actually repays the principal
+	if liability.StartDate == nil {
+		return badRequest("start_date is required when tenure_in_months is set")
+	}
+	if liability.EMI == 0 {
+		liability.EMI = helper.CalculateEMI(liability.Amount, liability.InterestRateInPercentage, liability.TenureInMonths)
+	}
+	if liability.EMI <= liability.Amount*liability.InterestRateInPercentage/(12*100) {
+		return badRequest("emi must be greater than the first month's interest")
+	}
+
+	return nil
+}
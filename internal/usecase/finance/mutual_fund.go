@@ -0,0 +1,143 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ImportMutualFundNAV reads an amfi NAVAll file, either uploaded as the multipart "file" field or from a path on the server,
+// stores the navs and revalues every unit based holding
+func (f FinanceUsecase) ImportMutualFundNAV(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var reader io.Reader
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := helper.ParseMultipartUpload(r, constant.MaxNAVUploadSizeInBytes); err != nil {
+			return nil, err
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, badRequest("file is required")
+		}
+		defer file.Close()
+		reader = file
+	} else {
+		var request entity.NAVImportRequest
+		if err := helper.DecodeRequestBody(r, &request); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(request.Path) == "" {
+			return nil, badRequest("either upload a file or give its path")
+		}
+		file, err := os.Open(request.Path)
+		if err != nil {
+			return nil, badRequest(fmt.Sprintf("cannot open %s", request.Path))
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	records, skipped, err := helper.ParseAMFINAVFile(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, badRequest("no nav found in the file")
+	}
+
+	schemes, navs, err := f.financeRepo.ImportMutualFundNAVs(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+
+	revalued, err := f.financeRepo.RevalueInvestmentsFromNAV(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Mutual fund nav imported successfully",
+			"result": entity.NAVImportResult{
+				SchemesImported:     schemes,
+				NAVsImported:        navs,
+				LinesSkipped:        skipped,
+				InvestmentsRevalued: revalued,
+			},
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetMutualFundNAVHistory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	// amfi scheme codes are numeric
+	code, err := helper.GetIdFromUrlParam(r, "schemeCode")
+	if err != nil {
+		return nil, err
+	}
+	schemeCode := strconv.FormatInt(code, 10)
+
+	scheme, err := f.financeRepo.GetMutualFundScheme(ctx, schemeCode)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "mutual fund scheme not found"}
+	}
+
+	navs, err := f.financeRepo.GetMutualFundNAVHistory(ctx, schemeCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Mutual fund nav history fetched successfully",
+			"scheme":  scheme,
+			"navs":    navs,
+		},
+		Success: true,
+	}, nil
+}
+
+// valueUnitHolding sets the amount of a holding linked to a scheme from its units and the latest nav
+func (f FinanceUsecase) valueUnitHolding(ctx context.Context, investment *entity.Investment) error {
+	if investment.SchemeCode != nil {
+		schemeCode := strings.TrimSpace(*investment.SchemeCode)
+		investment.SchemeCode = &schemeCode
+		if schemeCode == "" {
+			investment.SchemeCode = nil
+		}
+	}
+
+	if investment.SchemeCode == nil {
+		if investment.Units != nil {
+			return badRequest("units can only be given with a scheme_code")
+		}
+		return nil
+	}
+
+	if investment.Units == nil || *investment.Units <= 0 {
+		return badRequest("units must be greater than 0 for a scheme_code holding")
+	}
+
+	nav, err := f.financeRepo.GetLatestMutualFundNAV(ctx, *investment.SchemeCode)
+	if err != nil {
+		return err
+	}
+	if nav == nil {
+		return badRequest("scheme_code has no nav imported")
+	}
+
+	investment.Amount = helper.RoundToDecimals(*investment.Units*nav.NAV, 2)
+
+	return nil
+}
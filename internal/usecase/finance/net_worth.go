@@ -0,0 +1,183 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"master-finanacial-planner/internal/logger"
+	"math"
+	"net/http"
+	"time"
+)
+
+// TakeNetWorthSnapshot stores today's net worth as the snapshot of the current month, replacing an earlier one of the month
+func (f FinanceUsecase) TakeNetWorthSnapshot(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := f.computeNetWorthSnapshot(ctx, userId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.financeRepo.SaveNetWorthSnapshot(ctx, userId, snapshot, true); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":  "Net worth snapshot saved successfully",
+			"snapshot": snapshot,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetNetWorthHistory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := f.financeRepo.GetNetWorthSnapshots(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	byMonth := make(map[time.Time]entity.NetWorthSnapshot)
+	for _, snapshot := range snapshots {
+		byMonth[snapshot.SnapshotMonth.Time] = snapshot
+	}
+
+	history := []entity.NetWorthTrend{}
+	for _, snapshot := range snapshots {
+		trend := entity.NetWorthTrend{NetWorthSnapshot: snapshot}
+
+		// months without a snapshot leave the change empty instead of comparing with an older month
+		if previous, ok := byMonth[snapshot.SnapshotMonth.AddDate(0, -1, 0)]; ok {
+			trend.MonthOverMonth = netWorthChange(previous, snapshot)
+		}
+		if previous, ok := byMonth[snapshot.SnapshotMonth.AddDate(-1, 0, 0)]; ok {
+			trend.YearOverYear = netWorthChange(previous, snapshot)
+		}
+
+		history = append(history, trend)
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Net worth history fetched successfully",
+			"history": history,
+		},
+		Success: true,
+	}, nil
+}
+
+// SnapshotAllNetWorth takes the snapshot of the month for every user who does not have one yet
+func (f FinanceUsecase) SnapshotAllNetWorth(ctx context.Context, now time.Time) error {
+
+	userIds, err := f.financeRepo.GetAllUserIds(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failedUsers int
+	for _, userId := range userIds {
+		snapshot, err := f.computeNetWorthSnapshot(ctx, userId, now)
+		if err == nil {
+			var saved bool
+			saved, err = f.financeRepo.SaveNetWorthSnapshot(ctx, userId, snapshot, false)
+			if err == nil && saved {
+				logger.LogInfo(ctx, fmt.Sprintf("saved net worth snapshot of %s for user %d", snapshot.SnapshotMonth.Format("2006-01"), userId))
+			}
+		}
+		if err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error taking net worth snapshot for user %d: %v", userId, err))
+			failedUsers++
+		}
+	}
+
+	if failedUsers > 0 {
+		return fmt.Errorf("net worth snapshot failed for %d users", failedUsers)
+	}
+
+	return nil
+}
+
+// computeNetWorthSnapshot works out the net worth as of now, broken down by asset class and liquidity
+func (f FinanceUsecase) computeNetWorthSnapshot(ctx context.Context, userId int64, now time.Time) (*entity.NetWorthSnapshot, error) {
+
+	holdings, err := f.financeRepo.GetSubCategoryHoldings(ctx, userId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// liabilities, loans count with their outstanding principal as of today
+	liabilitiesAmount, err := f.getOutstandingLiabilities(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &entity.NetWorthSnapshot{
+		SnapshotMonth: entity.NewDate(time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)),
+		Liabilities:   helper.RoundToDecimals(liabilitiesAmount, 2),
+		AssetClasses:  []entity.NetWorthAssetClass{},
+	}
+
+	// holdings come per sub category, ordered by asset class
+	indexByAssetId := make(map[int64]int)
+	for _, holding := range holdings {
+		index, ok := indexByAssetId[holding.AssetId]
+		if !ok {
+			index = len(snapshot.AssetClasses)
+			indexByAssetId[holding.AssetId] = index
+			snapshot.AssetClasses = append(snapshot.AssetClasses, entity.NetWorthAssetClass{
+				AssetId:   holding.AssetId,
+				AssetName: holding.AssetName,
+			})
+		}
+		snapshot.AssetClasses[index].LiquidValue += holding.LiquidValue
+		snapshot.AssetClasses[index].IlliquidValue += holding.IlliquidValue
+		snapshot.AssetClasses[index].Value += holding.LiquidValue + holding.IlliquidValue
+
+		snapshot.LiquidAsset += holding.LiquidValue
+		snapshot.IlliquidAsset += holding.IlliquidValue
+	}
+
+	snapshot.TotalAsset = snapshot.LiquidAsset + snapshot.IlliquidAsset
+	snapshot.NetWorth = helper.RoundToDecimals(snapshot.TotalAsset-snapshot.Liabilities, 2)
+
+	return snapshot, nil
+}
+
+func netWorthChange(previous entity.NetWorthSnapshot, current entity.NetWorthSnapshot) *entity.NetWorthChange {
+	change := &entity.NetWorthChange{
+		NetWorth:      helper.RoundToDecimals(current.NetWorth-previous.NetWorth, 2),
+		TotalAsset:    helper.RoundToDecimals(current.TotalAsset-previous.TotalAsset, 2),
+		LiquidAsset:   helper.RoundToDecimals(current.LiquidAsset-previous.LiquidAsset, 2),
+		IlliquidAsset: helper.RoundToDecimals(current.IlliquidAsset-previous.IlliquidAsset, 2),
+		Liabilities:   helper.RoundToDecimals(current.Liabilities-previous.Liabilities, 2),
+		AssetClasses:  make(map[string]float64),
+	}
+
+	if previous.NetWorth != 0 {
+		change.NetWorthPercentage = helper.RoundToDecimals((current.NetWorth-previous.NetWorth)*100/math.Abs(previous.NetWorth), 2)
+	}
+
+	for _, assetClass := range previous.AssetClasses {
+		change.AssetClasses[assetClass.AssetName] -= assetClass.Value
+	}
+	for _, assetClass := range current.AssetClasses {
+		change.AssetClasses[assetClass.AssetName] += assetClass.Value
+	}
+	for name, value := range change.AssetClasses {
+		change.AssetClasses[name] = helper.RoundToDecimals(value, 2)
+	}
+
+	return change
+}
@@ -0,0 +1,147 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"master-finanacial-planner/internal/repo"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// postTaxReturnInputs is what post-tax allocation type returns are computed from, loaded once per request
+type postTaxReturnInputs struct {
+	configs  []repo.AllocationTypeConfig
+	blends   []entity.AllocationTypeBlend
+	profiles map[int64]entity.CapitalGainsTaxProfile
+}
+
+// GetPostTaxReturns compares the pre and post-tax return of the band every goal is invested in today.
+// With the years query param it also lists every allocation type for that horizon, without the ltcg exemption
+func (f FinanceUsecase) GetPostTaxReturns(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := f.getPostTaxReturnInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bands, err := f.getGlidePathBands(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goalsData, err := f.financeRepo.GetGoals(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	goalReturns := []entity.GoalPostTaxReturn{}
+	for _, goal := range goalsData {
+		if goal.IsDue {
+			continue
+		}
+
+		band, err := bandForYearsLeft(bands, goal.YearsLeft)
+		if err != nil {
+			return nil, err
+		}
+		targetAmount := helper.InflationCalculator(goal.TodayAmount, goal.YearsLeft, goal.InflationPercentage)
+
+		goalReturns = append(goalReturns, entity.GoalPostTaxReturn{
+			GoalId:             goal.ID,
+			GoalName:           goal.Name,
+			YearsLeft:          goal.YearsLeft,
+			AllocationTypeName: band.allocationType.Name,
+			PreTaxReturn:       band.returns,
+			PostTaxReturn:      inputs.returns(goal.YearsLeft, targetAmount)[band.allocationType.Name],
+		})
+	}
+
+	data := map[string]interface{}{
+		"message": "Post-tax returns fetched successfully",
+		"goals":   goalReturns,
+	}
+
+	if value := r.URL.Query().Get("years"); value != "" {
+		years, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || years < 1 || years > constant.MaxGoalYearsLeft {
+			return nil, badRequest(fmt.Sprintf("years must be between 1 and %d", constant.MaxGoalYearsLeft))
+		}
+
+		postTaxReturns := inputs.returns(years, 0)
+		allocationTypeReturns := []entity.AllocationTypeReturn{}
+		for _, band := range bands {
+			allocationTypeReturns = append(allocationTypeReturns, entity.AllocationTypeReturn{
+				AllocationTypeName: band.allocationType.Name,
+				PreTaxReturn:       band.returns,
+				PostTaxReturn:      postTaxReturns[band.allocationType.Name],
+			})
+		}
+		sort.Slice(allocationTypeReturns, func(i, j int) bool {
+			return allocationTypeReturns[i].AllocationTypeName < allocationTypeReturns[j].AllocationTypeName
+		})
+
+		data["years"] = years
+		data["allocation_types"] = allocationTypeReturns
+	}
+
+	return &entity.ApiResponse{
+		Data:    data,
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) getPostTaxReturnInputs(ctx context.Context) (*postTaxReturnInputs, error) {
+	configs, err := f.financeRepo.GetAllAllocationTypeConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blends, err := f.financeRepo.GetAllocationTypeBlends(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assetClasses, err := f.financeRepo.GetAssetClass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[int64]entity.CapitalGainsTaxProfile)
+	for _, assetClass := range assetClasses {
+		profiles[assetClass.ID] = assetClass.CapitalGainsTaxProfile
+	}
+
+	return &postTaxReturnInputs{configs: configs, blends: blends, profiles: profiles}, nil
+}
+
+// returns gives the post-tax effective return of every allocation type for a corpus redeemed after years,
+// each asset class sets its ltcg exemption off against its share of the corpus
+func (in postTaxReturnInputs) returns(years int64, corpus float64) map[string]float64 {
+	_, effectiveReturns := blendAllocationTypeReturns(in.configs, in.blends, func(row repo.AllocationTypeConfig) float64 {
+		profile := in.profiles[int64(row.AssetClassID)]
+		return helper.PostTaxReturn(row.AssetReturns, profile, years, corpus*row.AllocationInPercentage/100)
+	})
+	return effectiveReturns
+}
+
+// postTaxBands swaps the band returns for the post-tax returns of a goal, the whole corpus is redeemed when the goal is due
+func (in postTaxReturnInputs) postTaxBands(bands []glidePathBand, goal entity.Goals) []glidePathBand {
+	targetAmount := helper.InflationCalculator(goal.TodayAmount, goal.YearsLeft, goal.InflationPercentage)
+	postTaxReturns := in.returns(goal.YearsLeft, targetAmount)
+
+	goalBands := make([]glidePathBand, len(bands))
+	for i, band := range bands {
+		band.returns = postTaxReturns[band.allocationType.Name]
+		goalBands[i] = band
+	}
+	return goalBands
+}
@@ -0,0 +1,264 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetRebalanceTrades turns the gap between the current and the required investable allocation into buy and sell trades.
+// Query params: mode (full or sip-only), amount of fresh money (defaults to the investing surplus in sip-only mode)
+// and min_trade, trades below it are skipped
+func (f FinanceUsecase) GetRebalanceTrades(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := constant.RebalanceModeFull
+	if value := r.URL.Query().Get("mode"); value != "" {
+		mode = strings.ToLower(value)
+		if mode != constant.RebalanceModeFull && mode != constant.RebalanceModeSIPOnly {
+			return nil, badRequest(fmt.Sprintf("mode must be one of %s, %s", constant.RebalanceModeFull, constant.RebalanceModeSIPOnly))
+		}
+	}
+
+	minTrade := constant.DefaultMinTradeAmount
+	if value := r.URL.Query().Get("min_trade"); value != "" {
+		minTrade, err = strconv.ParseFloat(value, 64)
+		if err != nil || minTrade < 0 {
+			return nil, badRequest("min_trade must be a non negative number")
+		}
+	}
+
+	var freshAmount float64
+	if value := r.URL.Query().Get("amount"); value != "" {
+		freshAmount, err = strconv.ParseFloat(value, 64)
+		if err != nil || freshAmount < 0 {
+			return nil, badRequest("amount must be a non negative number")
+		}
+	} else if mode == constant.RebalanceModeSIPOnly {
+		investingSurplus, err := f.financeRepo.GetInvestingSurplus(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+		freshAmount = math.Max(investingSurplus, 0)
+	}
+
+	currentInvestableArr, err := f.financeRepo.GetCurrentInvestableData(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(currentInvestableArr, func(i, j int) bool {
+		return currentInvestableArr[i].AssetId < currentInvestableArr[j].AssetId
+	})
+
+	goalsData, err := f.financeRepo.GetGoals(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredInvestableAssetArr, err := f.getRequiredInvestableAssetAllocation(ctx, goalsData)
+	if err != nil {
+		return nil, err
+	}
+
+	targetPercentageByAssetId := make(map[int64]float64)
+	var totalTargetPercentage float64
+	for _, required := range requiredInvestableAssetArr {
+		targetPercentageByAssetId[required.AssetId] = required.ContributionPercentage
+		totalTargetPercentage += required.ContributionPercentage
+	}
+	if totalTargetPercentage == 0 {
+		return nil, &entity.CustomError{StatusCode: http.StatusUnprocessableEntity, Message: "no amount is allocated to goals, there is no target allocation to rebalance to"}
+	}
+
+	var currentTotal float64
+	for _, current := range currentInvestableArr {
+		currentTotal += current.Value
+	}
+	totalAfter := currentTotal + freshAmount
+
+	// gap of every asset class against its target share of the portfolio after the fresh money
+	tradeByAssetId := make(map[int64]float64)
+	var totalPositiveGap float64
+	for _, current := range currentInvestableArr {
+		gap := totalAfter*targetPercentageByAssetId[current.AssetId]/100 - current.Value
+		tradeByAssetId[current.AssetId] = gap
+		if gap > 0 {
+			totalPositiveGap += gap
+		}
+	}
+
+	if mode == constant.RebalanceModeSIPOnly {
+		directFreshAmount(tradeByAssetId, targetPercentageByAssetId, freshAmount, totalPositiveGap)
+	}
+
+	subCategoriesByAsset, err := f.getSubCategoriesByAssetClass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the emergency fund is never sold to rebalance
+	holdings, err := f.financeRepo.GetSubCategoryHoldings(ctx, userId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := []entity.RebalanceTrade{}
+	allocationAfter := []entity.RebalancedAllocation{}
+	var totalBought, totalSold float64
+
+	for _, current := range currentInvestableArr {
+		amount := helper.RoundToDecimals(tradeByAssetId[current.AssetId], 2)
+		if math.Abs(amount) < minTrade {
+			amount = 0
+		}
+
+		switch {
+		case amount > 0:
+			totalBought += amount
+			trades = append(trades, entity.RebalanceTrade{
+				AssetId:       current.AssetId,
+				AssetName:     current.AssetName,
+				Action:        constant.TradeActionBuy,
+				Amount:        amount,
+				SubCategories: splitBuyBySubCategory(amount, subCategoriesByAsset[current.AssetId], minTrade),
+			})
+		case amount < 0:
+			totalSold += -amount
+			trades = append(trades, entity.RebalanceTrade{
+				AssetId:       current.AssetId,
+				AssetName:     current.AssetName,
+				Action:        constant.TradeActionSell,
+				Amount:        -amount,
+				SubCategories: splitSellBySubCategory(-amount, current.AssetId, subCategoriesByAsset[current.AssetId], holdings),
+			})
+		}
+
+		allocationAfter = append(allocationAfter, entity.RebalancedAllocation{
+			AssetId:   current.AssetId,
+			AssetName: current.AssetName,
+			Current:   entity.ValueContribution{Value: current.Value},
+			Target: entity.ValueContribution{
+				Value:                  helper.RoundToDecimals(totalAfter*targetPercentageByAssetId[current.AssetId]/100, 2),
+				ContributionPercentage: helper.RoundToDecimals(targetPercentageByAssetId[current.AssetId], 2),
+			},
+			After: entity.ValueContribution{Value: helper.RoundToDecimals(current.Value+amount, 2)},
+		})
+	}
+
+	// contribution after the trades, skipped trades leave the total slightly off the planned one
+	var actualTotalAfter float64
+	for _, allocation := range allocationAfter {
+		actualTotalAfter += allocation.After.Value
+	}
+	for i := range allocationAfter {
+		if currentTotal != 0 {
+			allocationAfter[i].Current.ContributionPercentage = helper.RoundToDecimals(allocationAfter[i].Current.Value*100/currentTotal, 2)
+		}
+		if actualTotalAfter != 0 {
+			allocationAfter[i].After.ContributionPercentage = helper.RoundToDecimals(allocationAfter[i].After.Value*100/actualTotalAfter, 2)
+		}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":          "Rebalance trades fetched successfully",
+			"mode":             mode,
+			"fresh_amount":     freshAmount,
+			"min_trade_amount": minTrade,
+			"total_bought":     helper.RoundToDecimals(totalBought, 2),
+			"total_sold":       helper.RoundToDecimals(totalSold, 2),
+			"unallocated":      helper.RoundToDecimals(freshAmount+totalSold-totalBought, 2),
+			"trades":           trades,
+			"allocation_after": allocationAfter,
+		},
+		Success: true,
+	}, nil
+}
+
+// directFreshAmount replaces the gaps by buys funded only by the fresh money: the underweight asset classes get it
+// in proportion to their gap, anything left after closing every gap follows the target allocation
+func directFreshAmount(tradeByAssetId map[int64]float64, targetPercentageByAssetId map[int64]float64, freshAmount float64, totalPositiveGap float64) {
+	leftover := math.Max(freshAmount-totalPositiveGap, 0)
+
+	for assetId, gap := range tradeByAssetId {
+		buy := 0.0
+		if gap > 0 {
+			buy = gap
+			if totalPositiveGap > freshAmount {
+				buy = freshAmount * gap / totalPositiveGap
+			}
+		}
+		tradeByAssetId[assetId] = buy + leftover*targetPercentageByAssetId[assetId]/100
+	}
+}
+
+// splitBuyBySubCategory divides a buy like the sip allocator does, parts below the min trade are folded into the highest priority sub category
+func splitBuyBySubCategory(amount float64, subCategories []entity.AssetSubCategory, minTrade float64) []entity.SubCategoryAllocation {
+	parts := splitBySubCategory(amount, subCategories)
+
+	result := []entity.SubCategoryAllocation{}
+	var folded float64
+	for i, part := range parts {
+		if i > 0 && part.Value < minTrade {
+			folded += part.Value
+			continue
+		}
+		result = append(result, part)
+	}
+	if len(result) > 0 {
+		result[0].Value = helper.RoundToDecimals(result[0].Value+folded, 2)
+	}
+
+	return result
+}
+
+// splitSellBySubCategory sells the lowest priority sub categories first, holdings without a sub category go before all of them
+func splitSellBySubCategory(amount float64, assetId int64, subCategories []entity.AssetSubCategory, holdings []entity.SubCategoryHolding) []entity.SubCategoryAllocation {
+	priorityById := make(map[int64]int)
+	for _, subCategory := range subCategories {
+		priorityById[subCategory.ID] = subCategory.PriorityOrder
+	}
+
+	var assetHoldings []entity.SubCategoryHolding
+	for _, holding := range holdings {
+		if holding.AssetId == assetId && holding.LiquidValue > 0 {
+			assetHoldings = append(assetHoldings, holding)
+		}
+	}
+	sort.SliceStable(assetHoldings, func(i, j int) bool {
+		if (assetHoldings[i].SubCategoryId == 0) != (assetHoldings[j].SubCategoryId == 0) {
+			return assetHoldings[i].SubCategoryId == 0
+		}
+		return priorityById[assetHoldings[i].SubCategoryId] > priorityById[assetHoldings[j].SubCategoryId]
+	})
+
+	result := []entity.SubCategoryAllocation{}
+	remaining := amount
+	for _, holding := range assetHoldings {
+		if remaining <= 0 {
+			break
+		}
+		value := math.Min(remaining, holding.LiquidValue)
+		remaining -= value
+
+		result = append(result, entity.SubCategoryAllocation{
+			SubCategoryId:   holding.SubCategoryId,
+			SubCategoryName: holding.SubCategoryName,
+			PriorityOrder:   priorityById[holding.SubCategoryId],
+			Value:           helper.RoundToDecimals(value, 2),
+		})
+	}
+
+	return result
+}
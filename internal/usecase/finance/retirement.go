@@ -0,0 +1,61 @@
+package finance
+
+import (
+	"context"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+)
+
+func (f FinanceUsecase) RetirementCalculator(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	// fields missing in the body keep the defaults
+	request := entity.FireRequest{
+		GrowthRatePercentage: constant.DefaultFireGrowthRatePercentage,
+		LeanFireMultiplier:   constant.DefaultLeanFireMultiplier,
+		FireMultiplier:       constant.DefaultFireMultiplier,
+		FatFireMultiplier:    constant.DefaultFatFireMultiplier,
+	}
+	if err := helper.DecodeRequestBody(r, &request); err != nil {
+		return nil, err
+	}
+
+	if err := validateFireRequest(request); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":    "Retirement corpus calculated successfully",
+			"retirement": helper.FireCalculator(request),
+		},
+		Success: true,
+	}, nil
+}
+
+func validateFireRequest(request entity.FireRequest) error {
+	if request.CurrentAge <= 0 {
+		return badRequest("current_age must be greater than 0")
+	}
+	if request.RetirementAge < request.CurrentAge {
+		return badRequest("retirement_age cannot be less than current_age")
+	}
+	if request.EarlyRetirementAge < request.CurrentAge || request.EarlyRetirementAge > request.RetirementAge {
+		return badRequest("early_retirement_age must be between current_age and retirement_age")
+	}
+	if request.MonthlyExpense <= 0 {
+		return badRequest("monthly_expense must be greater than 0")
+	}
+	if request.InflationPercentage < 0 || request.InflationPercentage > constant.MaxPercentageAllowed {
+		return badRequest("inflation_percentage must be between 0 and 100")
+	}
+	if request.GrowthRatePercentage <= -100 {
+		return badRequest("growth_rate_percentage must be greater than -100")
+	}
+	if request.LeanFireMultiplier <= 0 || request.FireMultiplier <= 0 || request.FatFireMultiplier <= 0 {
+		return badRequest("fire multipliers must be greater than 0")
+	}
+
+	return nil
+}
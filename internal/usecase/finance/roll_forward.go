@@ -0,0 +1,158 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"master-finanacial-planner/internal/logger"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (f FinanceUsecase) RollForwardGoals(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currentYear := time.Now().Year()
+	planYear := currentYear
+	if value := r.URL.Query().Get("plan_year"); value != "" {
+		planYear, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, badRequest("invalid plan_year")
+		}
+	}
+
+	// rolling a future year would age the goals ahead of time
+	if planYear > currentYear {
+		return nil, badRequest("plan_year cannot be in the future")
+	}
+
+	history, err := f.financeRepo.GetGoalRollForwardHistory(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePlanYear(planYear, currentYear, history); err != nil {
+		return nil, err
+	}
+
+	rollForward, alreadyRan, err := f.financeRepo.RollForwardGoals(ctx, userId, planYear)
+	if err != nil {
+		return nil, err
+	}
+
+	message := "Goals rolled forward successfully"
+	if alreadyRan {
+		message = "Goals were already rolled forward for the plan year"
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":      message,
+			"already_ran":  alreadyRan,
+			"roll_forward": rollForward,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetGoalRollForwardHistory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := f.financeRepo.GetGoalRollForwardHistory(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Goal roll forward history fetched successfully",
+			"history": history,
+		},
+		Success: true,
+	}, nil
+}
+
+// RollForwardAllGoals is run by the scheduler, a failure for one user does not stop the others
+func (f FinanceUsecase) RollForwardAllGoals(ctx context.Context, planYear int) error {
+
+	userIds, err := f.financeRepo.GetUserIdsWithGoals(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failedUsers int
+	for _, userId := range userIds {
+		history, err := f.financeRepo.GetGoalRollForwardHistory(ctx, userId)
+		if err != nil {
+			logger.LogError(ctx, fmt.Sprintf("error fetching goal roll forward history for user %d: %v", userId, err))
+			failedUsers++
+			continue
+		}
+
+		// years missed since the last roll forward are caught up in order
+		for _, year := range pendingPlanYears(planYear, history) {
+			rollForward, alreadyRan, err := f.financeRepo.RollForwardGoals(ctx, userId, year)
+			if err != nil {
+				logger.LogError(ctx, fmt.Sprintf("error rolling forward goals for user %d: %v", userId, err))
+				failedUsers++
+				break
+			}
+			if !alreadyRan {
+				logger.LogInfo(ctx, fmt.Sprintf("rolled forward %d goals for user %d, plan year %d", rollForward.GoalsUpdated, userId, year))
+			}
+		}
+	}
+
+	if failedUsers > 0 {
+		return fmt.Errorf("goal roll forward failed for %d users", failedUsers)
+	}
+
+	return nil
+}
+
+// validatePlanYear accepts a year already rolled (which is a no-op) or the year right after the last one rolled,
+// the current year when nothing was rolled yet. Missed years have to be caught up one by one before the current
+// year, so goals are never aged twice and no year is skipped
+func validatePlanYear(planYear int, currentYear int, history []entity.GoalRollForward) error {
+	for _, record := range history {
+		if record.PlanYear == planYear {
+			return nil
+		}
+	}
+
+	// history is ordered latest plan year first
+	if len(history) == 0 {
+		if planYear != currentYear {
+			return badRequest("only the current plan year can be rolled forward")
+		}
+		return nil
+	}
+	lastPlanYear := history[0].PlanYear
+	if planYear != lastPlanYear+1 {
+		return badRequest(fmt.Sprintf("plan_year must be %d, the year after the last roll forward, missed years are rolled one by one", lastPlanYear+1))
+	}
+
+	return nil
+}
+
+// pendingPlanYears returns the years the scheduler still has to roll, oldest first, up to planYear
+func pendingPlanYears(planYear int, history []entity.GoalRollForward) []int {
+	if len(history) == 0 || history[0].PlanYear >= planYear {
+		return []int{planYear}
+	}
+
+	years := []int{}
+	for year := history[0].PlanYear + 1; year <= planYear; year++ {
+		years = append(years, year)
+	}
+	return years
+}
@@ -0,0 +1,135 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"math/rand"
+	"net/http"
+	"strconv"
+)
+
+// GetGoalSuccessProbability simulates every goal of the user with random yearly returns per asset class and reports
+// the chance of reaching the inflated target. The same seed always gives the same result
+func (f FinanceUsecase) GetGoalSuccessProbability(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := constant.MonteCarloDefaultSeed
+	if value := r.URL.Query().Get("seed"); value != "" {
+		seed, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, badRequest("invalid seed")
+		}
+	}
+
+	simulations := constant.MonteCarloDefaultSimulations
+	if value := r.URL.Query().Get("simulations"); value != "" {
+		simulations, err = strconv.Atoi(value)
+		if err != nil || simulations <= 0 || simulations > constant.MonteCarloMaxSimulations {
+			return nil, badRequest(fmt.Sprintf("simulations must be between 1 and %d", constant.MonteCarloMaxSimulations))
+		}
+	}
+
+	goalsData, err := f.financeRepo.GetGoals(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	assetClasses, err := f.financeRepo.GetAssetClass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	correlations, err := f.financeRepo.GetAssetClassCorrelations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cholesky, ok := correlationCholesky(assetClasses, correlations)
+	if !ok {
+		return nil, &entity.CustomError{StatusCode: http.StatusUnprocessableEntity, Message: "configured asset class correlations do not form a valid correlation matrix"}
+	}
+
+	results := []entity.GoalSimulationResult{}
+	for _, goal := range goalsData {
+
+		allocationConfigData, err := f.getAllocationTypeConfigByYearLeft(ctx, goal.YearsLeft)
+		if err != nil {
+			return nil, err
+		}
+
+		weightByAssetId := make(map[int64]float64)
+		for _, assetAllocationInfo := range allocationConfigData {
+			weightByAssetId[assetAllocationInfo.AssetId] += assetAllocationInfo.AllocationInPercentage
+		}
+
+		// every asset class is drawn so the correlations line up with the cholesky matrix, unused ones weigh 0
+		var expectedReturn float64
+		assets := make([]entity.SimulationAsset, len(assetClasses))
+		for i, assetClass := range assetClasses {
+			assets[i] = entity.SimulationAsset{
+				ExpectedReturnInPercentage: assetClass.ExpectedReturnInPercentage,
+				VolatilityInPercentage:     assetClass.VolatilityInPercentage,
+				WeightInPercentage:         weightByAssetId[assetClass.ID],
+			}
+			expectedReturn += assetClass.ExpectedReturnInPercentage * weightByAssetId[assetClass.ID] / 100
+		}
+
+		targetAmount := helper.InflationCalculator(goal.TodayAmount, goal.YearsLeft, goal.InflationPercentage)
+
+		monthlySIP := goal.CurrentSIP
+		sipSource := constant.SIPSourceCurrent
+		if monthlySIP == 0 {
+			// without a recorded sip, check how reliable the plan suggested by the sip allocator is
+			sipSource = constant.SIPSourceRequired
+			requiredAmount := targetAmount - goal.AllocatedAmount
+			if requiredAmount > 0 && goal.YearsLeft > 0 {
+				monthlySIP = helper.CalculateSIPRequired(requiredAmount, goal.YearsLeft, expectedReturn, goal.SIPStepUpPercentage)
+			}
+		}
+
+		// seeded per goal, so adding or removing a goal does not change the others
+		rng := rand.New(rand.NewSource(seed + goal.ID))
+		corpus := helper.SimulateCorpus(rng, assets, cholesky, goal.AllocatedAmount, monthlySIP, goal.SIPStepUpPercentage, goal.YearsLeft, simulations)
+
+		var successCount int
+		for _, value := range corpus {
+			if value >= targetAmount {
+				successCount++
+			}
+		}
+
+		results = append(results, entity.GoalSimulationResult{
+			GoalId:                         goal.ID,
+			GoalName:                       goal.Name,
+			YearsLeft:                      goal.YearsLeft,
+			TargetAmount:                   targetAmount,
+			MonthlySIP:                     monthlySIP,
+			SIPSource:                      sipSource,
+			SuccessProbabilityInPercentage: helper.RoundToDecimals(float64(successCount)*100/float64(simulations), 2),
+			Corpus: entity.CorpusPercentiles{
+				P10: helper.RoundToDecimals(helper.Percentile(corpus, 10), 2),
+				P25: helper.RoundToDecimals(helper.Percentile(corpus, 25), 2),
+				P50: helper.RoundToDecimals(helper.Percentile(corpus, 50), 2),
+				P75: helper.RoundToDecimals(helper.Percentile(corpus, 75), 2),
+				P90: helper.RoundToDecimals(helper.Percentile(corpus, 90), 2),
+			},
+		})
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":     "Goal success probability fetched successfully",
+			"seed":        seed,
+			"simulations": simulations,
+			"goals":       results,
+		},
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,225 @@
+package finance
+
+import (
+	"context"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (f FinanceUsecase) GetAssetSubCategories(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var assetClassId int64
+	if value := r.URL.Query().Get("asset_class_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, badRequest("invalid asset_class_id")
+		}
+		assetClassId = id
+	}
+
+	subCategories, err := f.financeRepo.GetAssetSubCategories(ctx, assetClassId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":        "Asset sub categories fetched successfully",
+			"sub_categories": subCategories,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) CreateAssetSubCategory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var subCategory entity.AssetSubCategory
+	if err := helper.DecodeRequestBody(r, &subCategory); err != nil {
+		return nil, err
+	}
+
+	if err := f.validateAssetSubCategory(ctx, &subCategory); err != nil {
+		return nil, err
+	}
+
+	createdSubCategory, err := f.financeRepo.CreateAssetSubCategory(ctx, subCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":      "Asset sub category created successfully",
+			"sub_category": createdSubCategory,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) UpdateAssetSubCategory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	subCategoryId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var subCategory entity.AssetSubCategory
+	if err := helper.DecodeRequestBody(r, &subCategory); err != nil {
+		return nil, err
+	}
+	subCategory.ID = subCategoryId
+
+	if err := f.validateAssetSubCategory(ctx, &subCategory); err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.UpdateAssetSubCategory(ctx, subCategory)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "asset sub category not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":      "Asset sub category updated successfully",
+			"sub_category": subCategory,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) DeleteAssetSubCategory(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	subCategoryId, err := helper.GetIdFromUrlParam(r, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := f.financeRepo.DeleteAssetSubCategory(ctx, subCategoryId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &entity.CustomError{StatusCode: http.StatusNotFound, Message: "asset sub category not found"}
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Asset sub category deleted successfully",
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) GetSubCategoryHoldings(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, err := f.financeRepo.GetSubCategoryHoldings(ctx, userId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":  "Sub category holdings fetched successfully",
+			"holdings": holdings,
+		},
+		Success: true,
+	}, nil
+}
+
+func (f FinanceUsecase) validateAssetSubCategory(ctx context.Context, subCategory *entity.AssetSubCategory) error {
+	subCategory.Name = strings.TrimSpace(subCategory.Name)
+
+	if subCategory.Name == "" {
+		return badRequest("name is required")
+	}
+	if subCategory.PriorityOrder <= 0 {
+		return badRequest("priority_order must be greater than 0")
+	}
+	if subCategory.WeightInPercentage < 0 || subCategory.WeightInPercentage > constant.MaxPercentageAllowed {
+		return badRequest("weight_in_percentage must be between 0 and 100")
+	}
+
+	assetClasses, err := f.financeRepo.GetAssetClass(ctx)
+	if err != nil {
+		return err
+	}
+	for _, assetClass := range assetClasses {
+		if assetClass.ID == subCategory.AssetClassId {
+			return nil
+		}
+	}
+
+	return badRequest("asset_class_id does not exist")
+}
+
+// getSubCategoriesByAssetClass groups the sub categories by asset class, in priority order
+func (f FinanceUsecase) getSubCategoriesByAssetClass(ctx context.Context) (map[int64][]entity.AssetSubCategory, error) {
+	subCategories, err := f.financeRepo.GetAssetSubCategories(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64][]entity.AssetSubCategory)
+	for _, subCategory := range subCategories {
+		result[subCategory.AssetClassId] = append(result[subCategory.AssetClassId], subCategory)
+	}
+
+	return result, nil
+}
+
+// splitBySubCategory divides the amount in proportion to the sub category weights.
+// When no weights are configured the whole amount goes to the highest priority sub category,
+// and the rounding residue is also given to the highest priority one so the parts add up to the amount.
+func splitBySubCategory(amount float64, subCategories []entity.AssetSubCategory) []entity.SubCategoryAllocation {
+	allocations := []entity.SubCategoryAllocation{}
+	if len(subCategories) == 0 {
+		return allocations
+	}
+
+	ordered := make([]entity.AssetSubCategory, len(subCategories))
+	copy(ordered, subCategories)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].PriorityOrder < ordered[j].PriorityOrder
+	})
+
+	var totalWeight float64
+	for _, subCategory := range ordered {
+		totalWeight += subCategory.WeightInPercentage
+	}
+
+	var allocated float64
+	for i, subCategory := range ordered {
+		var value float64
+		switch {
+		case totalWeight <= 0 && i == 0:
+			value = amount
+		case totalWeight > 0:
+			value = helper.RoundToDecimals(amount*subCategory.WeightInPercentage/totalWeight, 2)
+		}
+		allocated += value
+
+		allocations = append(allocations, entity.SubCategoryAllocation{
+			SubCategoryId:   subCategory.ID,
+			SubCategoryName: subCategory.Name,
+			PriorityOrder:   subCategory.PriorityOrder,
+			Value:           value,
+		})
+	}
+
+	allocations[0].Value = helper.RoundToDecimals(allocations[0].Value+amount-allocated, 2)
+
+	return allocations
+}
@@ -0,0 +1,296 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// incomeTaxEstimate is the tax of one financial year under every regime
+type incomeTaxEstimate struct {
+	financialYear     string
+	slabFinancialYear string // the latest year with slabs, when the estimated one has none yet
+	grossIncome       float64
+	salaryIncome      float64
+	deductions        []entity.TaxDeduction
+	estimates         map[string]entity.TaxEstimate
+	recommended       string
+}
+
+// GetTaxEstimate estimates the income tax of a financial year (query param, default the current one) under both regimes
+// and recommends the cheaper one. Cashflow inflows are taken as gross taxable income, so salary inflows should be
+// entered before tds. The yearly tax is then spread over 12 months to show what is left of the investing surplus
+func (f FinanceUsecase) GetTaxEstimate(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	userId, err := helper.GetUserIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	financialYear := r.URL.Query().Get("financial_year")
+	if financialYear == "" {
+		financialYear = helper.FinancialYearOf(time.Now())
+	}
+
+	estimate, err := f.estimateIncomeTax(ctx, userId, financialYear)
+	if err != nil {
+		return nil, err
+	}
+
+	investingSurplus, err := f.financeRepo.GetInvestingSurplus(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendedTax := estimate.estimates[estimate.recommended].TotalTax
+	var otherTax float64
+	for regime, regimeEstimate := range estimate.estimates {
+		if regime != estimate.recommended {
+			otherTax = regimeEstimate.TotalTax
+		}
+	}
+	monthlyTax := recommendedTax / 12
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":                     "Tax estimate fetched successfully",
+			"financial_year":              estimate.financialYear,
+			"slab_financial_year":         estimate.slabFinancialYear,
+			"gross_income":                helper.RoundToDecimals(estimate.grossIncome, 2),
+			"salary_income":               helper.RoundToDecimals(estimate.salaryIncome, 2),
+			"deductions":                  estimate.deductions,
+			"old_regime":                  estimate.estimates[constant.TaxRegimeOld],
+			"new_regime":                  estimate.estimates[constant.TaxRegimeNew],
+			"recommended_regime":          estimate.recommended,
+			"yearly_saving":               helper.RoundToDecimals(otherTax-recommendedTax, 2),
+			"monthly_tax":                 helper.RoundToDecimals(monthlyTax, 2),
+			"investing_surplus":           investingSurplus,
+			"investing_surplus_after_tax": helper.RoundToDecimals(investingSurplus-monthlyTax, 2),
+		},
+		Success: true,
+	}, nil
+}
+
+// estimateIncomeTax projects the inflows of the financial year from the cashflows and runs them through both regimes.
+// Deductions come from the buys and sips of tax tagged investments during the year, capped per section
+func (f FinanceUsecase) estimateIncomeTax(ctx context.Context, userId int64, financialYear string) (*incomeTaxEstimate, error) {
+
+	start, end, err := helper.FinancialYearRange(financialYear)
+	if err != nil {
+		return nil, badRequest(err.Error())
+	}
+
+	configs, slabFinancialYear, err := f.getTaxRegimeConfigs(ctx, financialYear)
+	if err != nil {
+		return nil, err
+	}
+
+	cashflows, err := f.financeRepo.GetCashflows(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &incomeTaxEstimate{
+		financialYear:     financialYear,
+		slabFinancialYear: slabFinancialYear,
+		deductions:        []entity.TaxDeduction{},
+		estimates:         make(map[string]entity.TaxEstimate),
+	}
+
+	// cashflow amounts are taken as of the start of the year
+	for month := 0; month < 12; month++ {
+		monthStart := start.AddDate(0, month, 0)
+		for _, cashflow := range cashflows {
+			if !cashflow.IsInflow {
+				continue
+			}
+			amount := helper.CashflowAmountInMonth(cashflow, start, monthStart)
+			estimate.grossIncome += amount
+			if cashflow.Category == constant.CashflowCategorySalary {
+				estimate.salaryIncome += amount
+			}
+		}
+	}
+
+	contributions, err := f.financeRepo.GetTaxSectionContributions(ctx, userId, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalDeductions float64
+	for _, section := range constant.TaxSections {
+		deduction := entity.TaxDeduction{
+			Section:  section,
+			Invested: helper.RoundToDecimals(contributions[section], 2),
+			Limit:    constant.TaxSectionLimits[section],
+		}
+		deduction.Claimed = min(deduction.Invested, deduction.Limit)
+		totalDeductions += deduction.Claimed
+		estimate.deductions = append(estimate.deductions, deduction)
+	}
+
+	for _, config := range configs {
+		// chapter VI-A deductions are not allowed under the new regime
+		deductions := totalDeductions
+		if config.Regime == constant.TaxRegimeNew {
+			deductions = 0
+		}
+		estimate.estimates[config.Regime] = helper.CalculateIncomeTax(config, estimate.grossIncome, estimate.salaryIncome, deductions)
+	}
+
+	// the new regime is the default one, so it wins a tie
+	estimate.recommended = constant.TaxRegimeNew
+	if estimate.estimates[constant.TaxRegimeOld].TotalTax < estimate.estimates[constant.TaxRegimeNew].TotalTax {
+		estimate.recommended = constant.TaxRegimeOld
+	}
+
+	return estimate, nil
+}
+
+// getTaxRegimeConfigs returns the regimes of the financial year. A year whose budget is not configured yet
+// falls back to the latest earlier year configured with both regimes
+func (f FinanceUsecase) getTaxRegimeConfigs(ctx context.Context, financialYear string) ([]entity.TaxRegimeConfig, string, error) {
+
+	configs, err := f.financeRepo.GetTaxRegimeConfigs(ctx, "")
+	if err != nil {
+		return nil, "", err
+	}
+
+	configsByYear := make(map[string][]entity.TaxRegimeConfig)
+	for _, config := range configs {
+		configsByYear[config.FinancialYear] = append(configsByYear[config.FinancialYear], config)
+	}
+
+	// financial years like 2025-26 sort as strings
+	slabFinancialYear := ""
+	for year, yearConfigs := range configsByYear {
+		if year <= financialYear && year > slabFinancialYear && len(yearConfigs) == len(constant.TaxRegimes) {
+			slabFinancialYear = year
+		}
+	}
+	if slabFinancialYear == "" {
+		return nil, "", &entity.CustomError{StatusCode: http.StatusUnprocessableEntity, Message: fmt.Sprintf("tax slabs of both regimes are not configured for %s", financialYear)}
+	}
+
+	return configsByYear[slabFinancialYear], slabFinancialYear, nil
+}
+
+// GetTaxSlabs returns the slab tables of a financial year (query param), or of every year without it
+func (f FinanceUsecase) GetTaxSlabs(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	financialYear := r.URL.Query().Get("financial_year")
+	if financialYear != "" {
+		if _, _, err := helper.FinancialYearRange(financialYear); err != nil {
+			return nil, badRequest(err.Error())
+		}
+	}
+
+	configs, err := f.financeRepo.GetTaxRegimeConfigs(ctx, financialYear)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "Tax slabs fetched successfully",
+			"regimes": configs,
+		},
+		Success: true,
+	}, nil
+}
+
+// UpdateTaxSlabs replaces the regimes and slab tables of one financial year
+func (f FinanceUsecase) UpdateTaxSlabs(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var request entity.TaxSlabUpdateRequest
+	if err := helper.DecodeRequestBody(r, &request); err != nil {
+		return nil, err
+	}
+
+	request.FinancialYear = strings.TrimSpace(request.FinancialYear)
+	if _, _, err := helper.FinancialYearRange(request.FinancialYear); err != nil {
+		return nil, badRequest(err.Error())
+	}
+	if len(request.Regimes) == 0 {
+		return nil, badRequest("regimes are required")
+	}
+
+	seen := make(map[string]bool)
+	for i := range request.Regimes {
+		request.Regimes[i].FinancialYear = request.FinancialYear
+		if err := validateTaxRegimeConfig(&request.Regimes[i]); err != nil {
+			return nil, err
+		}
+		if seen[request.Regimes[i].Regime] {
+			return nil, badRequest(fmt.Sprintf("regime %s is repeated", request.Regimes[i].Regime))
+		}
+		seen[request.Regimes[i].Regime] = true
+	}
+
+	if err := f.financeRepo.ReplaceTaxRegimeConfigs(ctx, request.FinancialYear, request.Regimes); err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message":        "Tax slabs updated successfully",
+			"financial_year": request.FinancialYear,
+			"regimes":        request.Regimes,
+		},
+		Success: true,
+	}, nil
+}
+
+// validateTaxRegimeConfig checks the slabs start at 0, follow each other without gaps and only the last one is open ended
+func validateTaxRegimeConfig(config *entity.TaxRegimeConfig) error {
+	config.Regime = strings.ToLower(strings.TrimSpace(config.Regime))
+
+	if !slices.Contains(constant.TaxRegimes, config.Regime) {
+		return badRequest("regime must be one of " + strings.Join(constant.TaxRegimes, ", "))
+	}
+	if config.StandardDeduction < 0 {
+		return badRequest("standard_deduction cannot be negative")
+	}
+	if config.RebateIncomeLimit < 0 {
+		return badRequest("rebate_income_limit cannot be negative")
+	}
+	if config.CessInPercentage < 0 || config.CessInPercentage > constant.MaxPercentageAllowed {
+		return badRequest("cess_in_percentage must be between 0 and 100")
+	}
+	if len(config.Slabs) == 0 {
+		return badRequest(fmt.Sprintf("slabs are required for the %s regime", config.Regime))
+	}
+
+	expectedLower := 0.0
+	for i, slab := range config.Slabs {
+		if slab.LowerLimit != expectedLower {
+			return badRequest(fmt.Sprintf("%s regime slab %d must start at %.2f", config.Regime, i+1, expectedLower))
+		}
+		if slab.RateInPercentage < 0 || slab.RateInPercentage > constant.MaxPercentageAllowed {
+			return badRequest("rate_in_percentage must be between 0 and 100")
+		}
+
+		last := i == len(config.Slabs)-1
+		if slab.UpperLimit == nil {
+			if !last {
+				return badRequest(fmt.Sprintf("only the last %s regime slab can be without an upper_limit", config.Regime))
+			}
+			continue
+		}
+		if last {
+			return badRequest(fmt.Sprintf("the last %s regime slab must be without an upper_limit", config.Regime))
+		}
+		if *slab.UpperLimit <= slab.LowerLimit {
+			return badRequest("upper_limit must be greater than lower_limit")
+		}
+		expectedLower = *slab.UpperLimit
+	}
+
+	return nil
+}
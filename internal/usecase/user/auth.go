@@ -0,0 +1,126 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"master-finanacial-planner/internal/constant"
+	"master-finanacial-planner/internal/entity"
+	"master-finanacial-planner/internal/helper"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+func (u UserUsecase) SignUpUsecase(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var request entity.SignUpRequest
+	if err := helper.DecodeRequestBody(r, &request); err != nil {
+		return nil, err
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	request.Email = strings.ToLower(strings.TrimSpace(request.Email))
+
+	if request.Name == "" {
+		return nil, &entity.CustomError{StatusCode: http.StatusBadRequest, Message: "name is required"}
+	}
+	if _, err := mail.ParseAddress(request.Email); err != nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusBadRequest, Message: "invalid email"}
+	}
+	if len(request.Password) < constant.MinPasswordLength {
+		return nil, &entity.CustomError{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("password must be at least %d characters", constant.MinPasswordLength)}
+	}
+
+	// check if the email is already registered
+	existingUser, err := u.userRepo.GetUserByEmail(ctx, request.Email)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser != nil {
+		return nil, &entity.CustomError{StatusCode: http.StatusConflict, Message: "email is already registered"}
+	}
+
+	salt, err := helper.GenerateSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.CreateUser(ctx, entity.User{
+		Name:         request.Name,
+		Email:        request.Email,
+		PasswordHash: helper.HashPassword(request.Password, salt),
+		PasswordSalt: salt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tokenResponse, err := generateAuthToken(*user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "User signed up successfully",
+			"auth":    tokenResponse,
+		},
+		Success: true,
+	}, nil
+}
+
+func (u UserUsecase) SignInUsecase(ctx context.Context, r *http.Request) (*entity.ApiResponse, error) {
+
+	var request entity.SignInRequest
+	if err := helper.DecodeRequestBody(r, &request); err != nil {
+		return nil, err
+	}
+
+	request.Email = strings.ToLower(strings.TrimSpace(request.Email))
+
+	user, err := u.userRepo.GetUserByEmail(ctx, request.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// same error for unknown email and wrong password
+	if user == nil || !helper.VerifyPassword(request.Password, user.PasswordSalt, user.PasswordHash) {
+		return nil, &entity.CustomError{StatusCode: http.StatusUnauthorized, Message: "invalid email or password"}
+	}
+
+	tokenResponse, err := generateAuthToken(*user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ApiResponse{
+		Data: map[string]interface{}{
+			"message": "User signed in successfully",
+			"auth":    tokenResponse,
+		},
+		Success: true,
+	}, nil
+}
+
+func generateAuthToken(user entity.User) (*entity.AuthTokenResponse, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(constant.TokenExpiryDuration)
+
+	token, err := helper.GenerateJWT(entity.JwtClaims{
+		UserId:    user.ID,
+		Email:     user.Email,
+		IsAdmin:   user.IsAdmin,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AuthTokenResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		User:      user,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-chi/chi"
 	"log"
@@ -8,7 +9,9 @@ import (
 	"master-finanacial-planner/internal/entity"
 	"master-finanacial-planner/internal/handler"
 	"master-finanacial-planner/internal/helper"
+	"master-finanacial-planner/internal/middleware"
 	"master-finanacial-planner/internal/repo"
+	"master-finanacial-planner/internal/scheduler"
 	"master-finanacial-planner/internal/usecase/finance"
 	"master-finanacial-planner/internal/usecase/user"
 	"net/http"
@@ -29,6 +32,14 @@ func main() {
 	userUsecase := user.NewUserUsecase(dataSourceRepo)
 	handler := handler.NewFinanceHandler(userUsecase, financeUsecase)
 
+	// background jobs
+	if helper.EnvBool(constant.GoalRollForwardSchedulerEnv, false) {
+		go scheduler.StartGoalRollForwardScheduler(context.Background(), financeUsecase, constant.GoalRollForwardSchedulerInterval)
+	}
+	if helper.EnvBool(constant.NetWorthSnapshotSchedulerEnv, false) {
+		go scheduler.StartNetWorthSnapshotScheduler(context.Background(), financeUsecase, constant.NetWorthSnapshotSchedulerInterval)
+	}
+
 	// setting up the route
 	router := chi.NewRouter()
 
@@ -45,27 +56,135 @@ func main() {
 	router.Post("/sign-up", handler.SignUpHandler)
 	router.Post("/sign-in", handler.SignInHandler)
 
-	// finance-route
+	// finance-route, only for authenticated users
+	router.Group(func(router chi.Router) {
+		router.Use(middleware.Authenticate)
+
+		// get routes
+		router.Get("/get/asset-classes", handler.GetAssetClassHandler)
+		// get effective returns on allocation type
+		router.Get("/get/allocation/effective-assets", handler.GetEffectiveReturnAllocationTypeHandler)
+		// blend definitions behind the effective returns
+		router.Get("/get/allocation/blends", handler.GetAllocationTypeBlendsHandler)
+		// investing surplus
+		router.Get("/investing-surplus", handler.GetInvestingSurplusHandler)
+		// investing
+		router.Get("/net-worth", handler.GetNetWorthHandler)
+		// monthly net worth snapshots and their trend
+		router.Post("/net-worth/snapshots", handler.TakeNetWorthSnapshotHandler)
+		router.Get("/net-worth/history", handler.GetNetWorthHistoryHandler)
+
+		// sip allocator
+		router.Get("/get/sip-allocator", handler.SipAllocatorHandler)
+
+		//investable asset allocation
+		router.Get("/analyse/investable-asset-allocation", handler.GetInvestableAssetAllocation)
+		// buy and sell trades that close the gaps of the investable asset allocation
+		router.Get("/analyse/rebalance", handler.GetRebalanceTradesHandler)
+		// monte carlo probability of reaching every goal
+		router.Get("/analyse/goal-success-probability", handler.GetGoalSuccessProbabilityHandler)
+		// year by year allocation of every goal as it moves through the allocation type bands
+		router.Get("/analyse/glide-path", handler.GetGlidePathHandler)
+		// allocation type returns after capital gains tax
+		router.Get("/analyse/post-tax-returns", handler.GetPostTaxReturnsHandler)
+
+		// goals
+		router.Post("/goals", handler.CreateGoalHandler)
+		router.Get("/goals/{id}", handler.GetGoalHandler)
+		router.Put("/goals/{id}", handler.UpdateGoalHandler)
+		router.Delete("/goals/{id}", handler.DeleteGoalHandler)
+		// move every goal one year closer, once per plan year
+		router.Post("/goals/roll-forward", handler.RollForwardGoalsHandler)
+		router.Get("/goals/roll-forward/history", handler.GetGoalRollForwardHistoryHandler)
+
+		// asset sub categories
+		router.Get("/asset-sub-categories", handler.GetAssetSubCategoriesHandler)
+		// holdings broken down by sub category
+		router.Get("/analyse/sub-category-holdings", handler.GetSubCategoryHoldingsHandler)
+
+		// cashflows
+		router.Get("/cashflows", handler.GetCashflowsHandler)
+		router.Post("/cashflows", handler.CreateCashflowHandler)
+		router.Get("/cashflows/{id}", handler.GetCashflowHandler)
+		router.Put("/cashflows/{id}", handler.UpdateCashflowHandler)
+		router.Delete("/cashflows/{id}", handler.DeleteCashflowHandler)
+		// bank statement import and its categorisation rules
+		router.Post("/cashflows/import", handler.ImportBankStatementHandler)
+		router.Get("/cashflow-rules", handler.GetCashflowRulesHandler)
+		router.Post("/cashflow-rules", handler.CreateCashflowRuleHandler)
+		router.Put("/cashflow-rules/{id}", handler.UpdateCashflowRuleHandler)
+		router.Delete("/cashflow-rules/{id}", handler.DeleteCashflowRuleHandler)
+		// investing surplus per cashflow category
+		router.Get("/investing-surplus/breakdown", handler.GetInvestingSurplusBreakdownHandler)
+		// monthly surplus projected from the dated cashflows
+		router.Get("/investing-surplus/projection", handler.GetSurplusProjectionHandler)
 
-	// get routes
-	router.Get("/get/asset-classes", handler.GetAssetClassHandler)
-	// get effective returns on allocation type
-	router.Get("/get/allocation/effective-assets", handler.GetEffectiveReturnAllocationTypeHandler)
-	// investing surplus
-	router.Get("/investing-surplus", handler.GetInvestingSurplusHandler)
-	// investing
-	router.Get("/net-worth", handler.GetNetWorthHandler)
+		// liabilities
+		router.Get("/liabilities", handler.GetLiabilitiesHandler)
+		router.Post("/liabilities", handler.CreateLiabilityHandler)
+		router.Get("/liabilities/amortization-schedule", handler.GetAmortizationSchedulesHandler)
+		router.Get("/liabilities/{id}", handler.GetLiabilityHandler)
+		router.Put("/liabilities/{id}", handler.UpdateLiabilityHandler)
+		router.Delete("/liabilities/{id}", handler.DeleteLiabilityHandler)
+		router.Get("/liabilities/{id}/amortization-schedule", handler.GetAmortizationScheduleHandler)
 
-	// sip allocator
-	router.Get("/get/sip-allocator", handler.SipAllocatorHandler)
+		// investment holdings
+		router.Get("/investments", handler.GetInvestmentsHandler)
+		router.Post("/investments", handler.CreateInvestmentHandler)
+		router.Get("/investments/{id}", handler.GetInvestmentHandler)
+		router.Put("/investments/{id}", handler.UpdateInvestmentHandler)
+		router.Delete("/investments/{id}", handler.DeleteInvestmentHandler)
+		// transaction ledger of a holding
+		router.Get("/investments/{id}/transactions", handler.GetInvestmentTransactionsHandler)
+		router.Post("/investments/{id}/transactions", handler.CreateInvestmentTransactionHandler)
+		router.Delete("/investments/{id}/transactions/{transactionId}", handler.DeleteInvestmentTransactionHandler)
+		// cost basis, gains, cagr and xirr from the ledger
+		router.Get("/analyse/investment-performance", handler.GetInvestmentPerformanceHandler)
+		// income tax under the old and new regimes
+		router.Get("/analyse/tax-estimate", handler.GetTaxEstimateHandler)
+		// realised capital gains per financial year and ltcg harvesting
+		router.Get("/analyse/capital-gains", handler.GetCapitalGainsReportHandler)
+		router.Get("/analyse/tax-harvesting", handler.GetTaxHarvestingSuggestionsHandler)
 
-	//investable asset allocation
-	router.Get("/analyse/investable-asset-allocation", handler.GetInvestableAssetAllocation)
+		// emergency fund adequacy and its top-up goal
+		router.Get("/emergency-fund", handler.GetEmergencyFundHandler)
+		router.Put("/emergency-fund", handler.UpdateEmergencyFundHandler)
 
-	// TODO retirement-calculator api
-	// TODO: asset sub division
-	// Todo: Decrement Year api
-	// Todo: add/update goals api
+		// mutual fund nav history per amfi scheme code
+		router.Get("/mutual-funds/{schemeCode}/nav-history", handler.GetMutualFundNAVHistoryHandler)
+
+		// retirement calculator
+		router.Post("/calculate/retirement", handler.RetirementCalculatorHandler)
+
+		// admin-route, asset classes and allocation types are shared by every user
+		router.Route("/admin", func(router chi.Router) {
+			router.Use(middleware.RequireAdmin)
+
+			router.Post("/asset-classes", handler.CreateAssetClassHandler)
+			router.Put("/asset-classes/{id}", handler.UpdateAssetClassHandler)
+			router.Delete("/asset-classes/{id}", handler.DeleteAssetClassHandler)
+
+			router.Post("/asset-sub-categories", handler.CreateAssetSubCategoryHandler)
+			router.Put("/asset-sub-categories/{id}", handler.UpdateAssetSubCategoryHandler)
+			router.Delete("/asset-sub-categories/{id}", handler.DeleteAssetSubCategoryHandler)
+
+			router.Get("/allocation-types", handler.GetAllocationTypesHandler)
+			router.Post("/allocation-types", handler.CreateAllocationTypeHandler)
+			router.Put("/allocation-types/age-bands", handler.UpdateAllocationTypeAgeBandsHandler)
+			router.Put("/allocation-types/{id}", handler.UpdateAllocationTypeHandler)
+			router.Delete("/allocation-types/{id}", handler.DeleteAllocationTypeHandler)
+			router.Put("/allocation-types/{id}/config", handler.UpdateAllocationTypeConfigHandler)
+			router.Put("/allocation-types/{id}/blend", handler.UpdateAllocationTypeBlendHandler)
+
+			router.Get("/asset-class-correlations", handler.GetAssetClassCorrelationsHandler)
+			router.Put("/asset-class-correlations", handler.UpdateAssetClassCorrelationsHandler)
+			// income tax slab tables per financial year
+			router.Get("/tax-slabs", handler.GetTaxSlabsHandler)
+			router.Put("/tax-slabs", handler.UpdateTaxSlabsHandler)
+
+			router.Post("/mutual-funds/nav-import", handler.ImportMutualFundNAVHandler)
+		})
+	})
 
 	fmt.Printf("Master-financial Server Started at port %s\n", constant.ConfigPort)
 	err = http.ListenAndServe(constant.ConfigPort, router)
@@ -109,3 +109,370 @@ create table if not exists public.investments
 alter table public.investments
     owner to myuser;
 
+create table if not exists public.users
+(
+    id            bigserial
+    primary key,
+    name          varchar(255)                        not null,
+    email         varchar(255)                        not null
+    unique,
+    password_hash varchar(255)                        not null,
+    password_salt varchar(255)                        not null,
+    created_at    timestamp default CURRENT_TIMESTAMP not null
+    );
+
+alter table public.users
+    owner to myuser;
+
+-- per-user ownership of the finance tables. user_id stays nullable here so this runs on a database without accounts,
+-- DDL_backfill_user_id.sql assigns the older rows and makes it not null once the first user has signed up
+alter table public.goals
+    add column if not exists user_id bigint references public.users;
+
+alter table public.cashflow
+    add column if not exists user_id bigint references public.users;
+
+alter table public.liabilities
+    add column if not exists user_id bigint references public.users;
+
+alter table public.investments
+    add column if not exists user_id bigint references public.users;
+
+create index if not exists goals_user_id_idx on public.goals (user_id);
+create index if not exists cashflow_user_id_idx on public.cashflow (user_id);
+create index if not exists liabilities_user_id_idx on public.liabilities (user_id);
+create index if not exists investments_user_id_idx on public.investments (user_id);
+
+alter table public.goals
+    add column if not exists is_due boolean default false not null;
+
+create table if not exists public.goal_roll_forward
+(
+    id            bigserial
+    primary key,
+    user_id       bigint                              not null
+    references public.users,
+    plan_year     integer                             not null,
+    goals_updated integer                             not null,
+    ran_at        timestamp default CURRENT_TIMESTAMP not null,
+    unique (user_id, plan_year)
+    );
+
+alter table public.goal_roll_forward
+    owner to myuser;
+
+-- asset sub category weights, ids are generated from now on
+create sequence if not exists public.asset_sub_category_id_seq owned by public.asset_sub_category.id;
+
+select setval('public.asset_sub_category_id_seq', coalesce((select max(id) from public.asset_sub_category), 0) + 1, false);
+
+alter table public.asset_sub_category
+    alter column id set default nextval('public.asset_sub_category_id_seq'::regclass);
+
+alter table public.asset_sub_category
+    add column if not exists weight_in_percentage double precision default 0.0 not null;
+
+alter table public.cashflow
+    add column if not exists category varchar(50) default 'other' not null;
+
+alter table public.cashflow
+    drop constraint if exists cashflow_category_check;
+
+alter table public.cashflow
+    add constraint cashflow_category_check
+    check ((category)::text = ANY ((ARRAY ['salary'::character varying, 'rent'::character varying, 'emi'::character varying, 'discretionary'::character varying, 'other'::character varying])::text[]));
+
+-- emi plan of a loan, tenure_in_months = 0 for liabilities without one
+alter table public.liabilities
+    add column if not exists interest_rate_in_percentage double precision default 0.0 not null;
+
+alter table public.liabilities
+    add column if not exists tenure_in_months integer default 0 not null;
+
+alter table public.liabilities
+    add column if not exists emi double precision default 0.0 not null;
+
+alter table public.liabilities
+    add column if not exists start_date date;
+
+-- liquidity types are stored in lower case so every query matches 'liquid' / 'illiquid'
+alter table public.investments
+    drop constraint if exists investments_type_check;
+
+update public.investments
+set type = lower(type)
+where type <> lower(type);
+
+alter table public.investments
+    add constraint investments_type_check
+    check ((type)::text = ANY ((ARRAY ['liquid'::character varying, 'illiquid'::character varying])::text[]));
+
+alter table public.users
+    add column if not exists is_admin boolean default false not null;
+
+create table if not exists public.allocation_type_blend
+(
+    id                           bigserial
+    primary key,
+    allocation_type_id           bigint           not null
+    references public.allocation_type,
+    component_allocation_type_id bigint           not null
+    references public.allocation_type,
+    weight_in_percentage         double precision not null,
+    unique (allocation_type_id, component_allocation_type_id)
+    );
+
+alter table public.allocation_type_blend
+    owner to myuser;
+
+-- the medium-term return used to be hardcoded as 60% short-term + 40% medium-term
+insert into public.allocation_type_blend (allocation_type_id, component_allocation_type_id, weight_in_percentage)
+select medium.id, component.id, case when component.name = 'short-term' then 60 else 40 end
+from public.allocation_type medium
+join public.allocation_type component
+    on component.name in ('short-term', 'medium-term')
+where medium.name = 'medium-term'
+on conflict (allocation_type_id, component_allocation_type_id) do nothing;
+
+
+alter table public.asset_class
+    add column if not exists volatility_in_percentage double precision default 0 not null;
+
+alter table public.goals
+    add column if not exists current_sip double precision default 0 not null;
+
+create table if not exists public.asset_class_correlation
+(
+    asset_class_id_a bigint           not null
+    references public.asset_class
+    on delete cascade,
+    asset_class_id_b bigint           not null
+    references public.asset_class
+    on delete cascade,
+    correlation      double precision not null
+    check (correlation >= -1 and correlation <= 1),
+    primary key (asset_class_id_a, asset_class_id_b),
+    check (asset_class_id_a < asset_class_id_b)
+    );
+
+alter table public.asset_class_correlation
+    owner to myuser;
+
+alter table public.goals
+    add column if not exists priority integer default 1 not null;
+
+create table if not exists public.net_worth_snapshot
+(
+    id             bigserial
+    primary key,
+    user_id        bigint                                 not null
+    references public.users,
+    snapshot_month date                                   not null,
+    total_asset    double precision                       not null,
+    liquid_asset   double precision                       not null,
+    illiquid_asset double precision                       not null,
+    liabilities    double precision                       not null,
+    net_worth      double precision                       not null,
+    created_at     timestamp with time zone default now() not null,
+    unique (user_id, snapshot_month)
+    );
+
+alter table public.net_worth_snapshot
+    owner to myuser;
+
+create table if not exists public.net_worth_snapshot_asset
+(
+    snapshot_id      bigint           not null
+    references public.net_worth_snapshot
+    on delete cascade,
+    asset_class_id   bigint           not null,
+    asset_class_name varchar          not null,
+    liquid_value     double precision not null,
+    illiquid_value   double precision not null,
+    primary key (snapshot_id, asset_class_id)
+    );
+
+alter table public.net_worth_snapshot_asset
+    owner to myuser;
+
+create table if not exists public.investment_transaction
+(
+    id               bigserial
+    primary key,
+    user_id          bigint           not null
+    references public.users,
+    investment_id    bigint           not null
+    references public.investments
+    on delete cascade,
+    type             varchar          not null
+    constraint investment_transaction_type_check
+    check ((type)::text = ANY ((ARRAY ['buy'::character varying, 'sell'::character varying, 'dividend'::character varying, 'sip'::character varying])::text[])),
+    transaction_date date             not null,
+    amount           double precision not null,
+    units            double precision default 0 not null
+    );
+
+alter table public.investment_transaction
+    owner to myuser;
+
+create index if not exists investment_transaction_investment_id_idx on public.investment_transaction (investment_id);
+
+create table if not exists public.mutual_fund_scheme
+(
+    scheme_code       varchar not null
+    primary key,
+    scheme_name       varchar not null,
+    isin_growth       varchar default '' not null,
+    isin_reinvestment varchar default '' not null,
+    fund_house        varchar default '' not null,
+    category          varchar default '' not null
+    );
+
+alter table public.mutual_fund_scheme
+    owner to myuser;
+
+create table if not exists public.mutual_fund_nav
+(
+    scheme_code varchar          not null
+    references public.mutual_fund_scheme,
+    nav_date    date             not null,
+    nav         double precision not null,
+    primary key (scheme_code, nav_date)
+    );
+
+alter table public.mutual_fund_nav
+    owner to myuser;
+
+-- unit based holdings, the amount of a holding with a scheme code is units x latest nav
+alter table public.investments
+    add column if not exists scheme_code varchar references public.mutual_fund_scheme,
+    add column if not exists units double precision;
+
+alter table public.cashflow
+    add column if not exists source varchar default 'manual' not null;
+
+create table if not exists public.cashflow_category_rule
+(
+    id       bigserial
+    primary key,
+    user_id  bigint            not null
+    references public.users,
+    pattern  varchar           not null,
+    category varchar           not null,
+    priority integer default 1 not null
+    );
+
+alter table public.cashflow_category_rule
+    owner to myuser;
+
+create index if not exists cashflow_category_rule_user_id_idx on public.cashflow_category_rule (user_id);
+
+create table if not exists public.bank_transaction
+(
+    id               bigserial
+    primary key,
+    user_id          bigint                                 not null
+    references public.users,
+    transaction_date date                                   not null,
+    narration        varchar                                not null,
+    amount           double precision                       not null,
+    is_inflow        boolean                                not null,
+    category         varchar                                not null,
+    fingerprint      varchar                                not null,
+    imported_at      timestamp with time zone default now() not null,
+    unique (user_id, fingerprint)
+    );
+
+alter table public.bank_transaction
+    owner to myuser;
+
+alter table public.cashflow
+    add column if not exists frequency varchar default 'monthly' not null,
+    add column if not exists start_date date,
+    add column if not exists end_date date,
+    add column if not exists growth_rate_in_percentage double precision default 0 not null;
+
+alter table public.investments
+    add column if not exists tax_section varchar;
+
+create table if not exists public.tax_regime_config
+(
+    id                  bigserial
+    primary key,
+    financial_year      varchar                        not null,
+    regime              varchar                        not null
+    constraint tax_regime_config_regime_check
+    check ((regime)::text = ANY ((ARRAY ['old'::character varying, 'new'::character varying])::text[])),
+    standard_deduction  double precision default 0     not null,
+    rebate_income_limit double precision default 0     not null,
+    marginal_relief     boolean          default false not null,
+    cess_in_percentage  double precision default 4     not null,
+    unique (financial_year, regime)
+    );
+
+alter table public.tax_regime_config
+    owner to myuser;
+
+create table if not exists public.tax_slab
+(
+    id                   bigserial
+    primary key,
+    tax_regime_config_id bigint           not null
+    references public.tax_regime_config
+    on delete cascade,
+    lower_limit          double precision not null,
+    upper_limit          double precision,
+    rate_in_percentage   double precision not null
+    );
+
+alter table public.tax_slab
+    owner to myuser;
+
+-- slabs of FY 2025-26, later years are maintained through the admin api
+insert into public.tax_regime_config (financial_year, regime, standard_deduction, rebate_income_limit, marginal_relief, cess_in_percentage)
+values ('2025-26', 'old', 50000, 500000, false, 4),
+       ('2025-26', 'new', 75000, 1200000, true, 4)
+on conflict (financial_year, regime) do nothing;
+
+insert into public.tax_slab (tax_regime_config_id, lower_limit, upper_limit, rate_in_percentage)
+select c.id, slab.lower_limit, slab.upper_limit, slab.rate_in_percentage
+from public.tax_regime_config c
+join (values ('old', 0, 250000, 0),
+             ('old', 250000, 500000, 5),
+             ('old', 500000, 1000000, 20),
+             ('old', 1000000, null, 30),
+             ('new', 0, 400000, 0),
+             ('new', 400000, 800000, 5),
+             ('new', 800000, 1200000, 10),
+             ('new', 1200000, 1600000, 15),
+             ('new', 1600000, 2000000, 20),
+             ('new', 2000000, 2400000, 25),
+             ('new', 2400000, null, 30)) as slab (regime, lower_limit, upper_limit, rate_in_percentage)
+    on slab.regime = c.regime
+where c.financial_year = '2025-26'
+  and not exists (select 1 from public.tax_slab s where s.tax_regime_config_id = c.id);
+
+alter table public.asset_class
+    add column if not exists stcg_rate_in_percentage double precision default 0 not null,
+    add column if not exists ltcg_rate_in_percentage double precision default 0 not null,
+    add column if not exists ltcg_holding_period_in_months integer default 12 not null,
+    add column if not exists ltcg_exemption_limit double precision default 0 not null,
+    add column if not exists indexation boolean default false not null;
+
+alter table public.investments
+    add column if not exists is_emergency_fund boolean default false not null;
+
+create table if not exists public.emergency_fund_config
+(
+    user_id        bigint                         not null
+    primary key
+    references public.users,
+    target_months  double precision default 6     not null,
+    top_up_goal    boolean          default false not null,
+    top_up_goal_id bigint
+    references public.goals
+    on delete set null
+    );
+
+alter table public.emergency_fund_config
+    owner to myuser;
@@ -0,0 +1,23 @@
+-- second step of the per-user ownership, run after the first account has signed up.
+-- rows from before accounts existed go to the first user. Without any user the not null constraints fail
+-- and nothing is changed, rather than leaving rows nobody can see
+begin;
+
+update public.goals set user_id = (select min(id) from public.users) where user_id is null;
+update public.cashflow set user_id = (select min(id) from public.users) where user_id is null;
+update public.liabilities set user_id = (select min(id) from public.users) where user_id is null;
+update public.investments set user_id = (select min(id) from public.users) where user_id is null;
+
+alter table public.goals
+    alter column user_id set not null;
+
+alter table public.cashflow
+    alter column user_id set not null;
+
+alter table public.liabilities
+    alter column user_id set not null;
+
+alter table public.investments
+    alter column user_id set not null;
+
+commit;